@@ -1,13 +1,18 @@
 package config
 
 import (
+	"fmt"
+
 	kafka "github.com/opensourceways/kafka-lib/agent"
 	"github.com/opensourceways/server-common-lib/postgre"
 	"github.com/opensourceways/server-common-lib/utils"
 
 	"github.com/opensourceways/defect-manager/defect/infrastructure/backendimpl"
 	"github.com/opensourceways/defect-manager/defect/infrastructure/bulletinimpl"
+	"github.com/opensourceways/defect-manager/defect/infrastructure/gitimpl"
+	"github.com/opensourceways/defect-manager/defect/infrastructure/localimpl"
 	"github.com/opensourceways/defect-manager/defect/infrastructure/obsimpl"
+	"github.com/opensourceways/defect-manager/defect/infrastructure/osvimpl"
 	"github.com/opensourceways/defect-manager/defect/infrastructure/producttreeimpl"
 	"github.com/opensourceways/defect-manager/defect/infrastructure/repositoryimpl"
 	"github.com/opensourceways/defect-manager/issue"
@@ -28,6 +33,13 @@ func LoadConfig(path string) (*Config, error) {
 	return cfg, nil
 }
 
+const (
+	StorageObs   = "obs"
+	StorageLocal = "local"
+	StorageS3    = "s3"
+	StorageGit   = "git"
+)
+
 type configValidate interface {
 	Validate() error
 }
@@ -42,9 +54,16 @@ type Config struct {
 	Issue         issue.Config           `json:"issue"          required:"true"`
 	Postgres      postgres.Config        `json:"postgres"       required:"true"`
 	ProductTree   producttreeimpl.Config `json:"product_tree"   required:"true"`
-	Obs           obsimpl.Config         `json:"obs"            required:"true"`
 	Backend       backendimpl.Config     `json:"backend"        required:"true"`
 	Bulletin      bulletinimpl.Config    `json:"bulletin"`
+	Osv           osvimpl.Config         `json:"osv"`
+
+	// Storage is where the bulletins are uploaded to, obs, local, s3 or git
+	Storage string           `json:"storage"`
+	Obs     obsimpl.Config   `json:"obs"`
+	Local   localimpl.Config `json:"local"`
+	S3      obsimpl.S3Config `json:"s3"`
+	Git     gitimpl.Config   `json:"git"`
 
 	repositoryimpl.Config
 }
@@ -56,13 +75,57 @@ func (cfg *Config) configItems() []interface{} {
 		&cfg.Issue,
 		&cfg.Postgres,
 		&cfg.ProductTree,
-		&cfg.Obs,
 		&cfg.Backend,
 		&cfg.Bulletin,
+		&cfg.Osv,
+		cfg.storage(),
+		&cfg.Config,
+	}
+}
+
+// storage returns the config of the storage selected, it is nil if the storage is invalid
+func (cfg *Config) storage() interface{} {
+	switch cfg.Storage {
+	case StorageObs:
+		return &cfg.Obs
+	case StorageLocal:
+		return &cfg.Local
+	case StorageS3:
+		return &cfg.S3
+	case StorageGit:
+		return &cfg.Git
+	default:
+		return nil
+	}
+}
+
+// dropUnusedStorages clears the configs of the storages which are not selected,
+// so that they are neither defaulted nor checked.
+func (cfg *Config) dropUnusedStorages() {
+	if cfg.Storage != StorageObs {
+		cfg.Obs = obsimpl.Config{}
+	}
+
+	if cfg.Storage != StorageLocal {
+		cfg.Local = localimpl.Config{}
+	}
+
+	if cfg.Storage != StorageS3 {
+		cfg.S3 = obsimpl.S3Config{}
+	}
+
+	if cfg.Storage != StorageGit {
+		cfg.Git = gitimpl.Config{}
 	}
 }
 
 func (cfg *Config) SetDefault() {
+	if cfg.Storage == "" {
+		cfg.Storage = StorageObs
+	}
+
+	cfg.dropUnusedStorages()
+
 	items := cfg.configItems()
 	for _, i := range items {
 		if f, ok := i.(configSetDefault); ok {
@@ -72,10 +135,20 @@ func (cfg *Config) SetDefault() {
 }
 
 func (cfg *Config) Validate() error {
+	storage := cfg.storage()
+	if storage == nil {
+		return fmt.Errorf("invalid storage: %s", cfg.Storage)
+	}
+
 	if _, err := utils.BuildRequestBody(cfg, ""); err != nil {
 		return err
 	}
 
+	// the config of storage is checked even if it is empty
+	if _, err := utils.BuildRequestBody(storage, cfg.Storage); err != nil {
+		return err
+	}
+
 	items := cfg.configItems()
 	for _, i := range items {
 		if f, ok := i.(configValidate); ok {
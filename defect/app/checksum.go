@@ -0,0 +1,88 @@
+package app
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	// checksumExt is the extension of checksum file, the content of which is the same as the output of sha256sum
+	checksumExt = ".sha256"
+	// signatureExt is the extension of the armored detached signature
+	signatureExt = ".asc"
+)
+
+func checksumLine(sum, name string) string {
+	return fmt.Sprintf("%s  %s\n", sum, name)
+}
+
+// checksumManifest records the checksums of the files uploaded in a run,
+// the nil one records nothing.
+type checksumManifest struct {
+	lines []string
+}
+
+func (m *checksumManifest) add(sum, p string) {
+	if m != nil {
+		m.lines = append(m.lines, checksumLine(sum, p))
+	}
+}
+
+func (m *checksumManifest) isEmpty() bool {
+	return m == nil || len(m.lines) == 0
+}
+
+func (m *checksumManifest) data() []byte {
+	return []byte(strings.Join(m.lines, ""))
+}
+
+// manifestName is the name of manifest of the job, or of the revision of a bulletin re-issued
+func manifestName(id string) string {
+	return fmt.Sprintf("manifest-%s%s", id, checksumExt)
+}
+
+// uploadManifest uploads the manifest if any document is recorded in it
+func (d defectService) uploadManifest(id string, m *checksumManifest) error {
+	if m.isEmpty() {
+		return nil
+	}
+
+	name := d.filePath(manifestName(id))
+	if err := d.obs.Upload(name, m.data()); err != nil {
+		return fmt.Errorf("upload %s error: %s", name, err.Error())
+	}
+
+	return nil
+}
+
+// uploadDocument uploads the document of bulletin with its checksum file and signature,
+// and records it in the manifest. The document is not signed if the signer is not configured.
+func (d defectService) uploadDocument(p string, data []byte, m *checksumManifest) error {
+	if err := d.obs.Upload(p, data); err != nil {
+		return err
+	}
+
+	sum := fmt.Sprintf("%x", sha256.Sum256(data))
+	if err := d.obs.Upload(p+checksumExt, []byte(checksumLine(sum, path.Base(p)))); err != nil {
+		return fmt.Errorf("upload checksum error: %s", err.Error())
+	}
+
+	m.add(sum, p)
+
+	if d.signer == nil {
+		return nil
+	}
+
+	sig, err := d.signer.Sign(data)
+	if err == nil {
+		err = d.obs.Upload(p+signatureExt, sig)
+	}
+
+	if err != nil {
+		return fmt.Errorf("upload signature error: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUploadDocument(t *testing.T) {
+	storage := storageTest{}
+	d := defectService{obs: storage, clock: &clockTest{now: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)}}
+
+	manifest := new(checksumManifest)
+	for _, v := range []string{"cvrf-openEuler-BA-2024-1001.xml", "cvrf-openEuler-BA-2024-1001.json"} {
+		if err := d.uploadDocument(d.filePath(v), []byte("abc"), manifest); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sum := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+
+	if got, want := storage["2024-03-01/cvrf-openEuler-BA-2024-1001.xml.sha256"],
+		sum+"  cvrf-openEuler-BA-2024-1001.xml\n"; got != want {
+		t.Errorf("want checksum %q, got %q", want, got)
+	}
+
+	want := sum + "  2024-03-01/cvrf-openEuler-BA-2024-1001.xml\n" +
+		sum + "  2024-03-01/cvrf-openEuler-BA-2024-1001.json\n"
+	if got := string(manifest.data()); got != want {
+		t.Errorf("want manifest %q, got %q", want, got)
+	}
+
+	// the nil manifest records nothing
+	if err := d.uploadDocument(d.filePath("cvrf-openEuler-BA-2024-1002.xml"), []byte("abc"), nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type signerTest struct{}
+
+func (s signerTest) Sign(data []byte) ([]byte, error) {
+	return append([]byte("signature of "), data...), nil
+}
+
+func (s signerTest) Fingerprint() string {
+	return "0123456789ABCDEF"
+}
+
+func TestUploadDocumentSigned(t *testing.T) {
+	storage := storageTest{}
+	d := defectService{
+		obs:    storage,
+		signer: signerTest{},
+		clock:  &clockTest{now: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)},
+	}
+
+	if err := d.uploadDocument(d.filePath("cvrf-openEuler-BA-2024-1001.xml"), []byte("abc"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := storage["2024-03-01/cvrf-openEuler-BA-2024-1001.xml.asc"]; got != "signature of abc" {
+		t.Errorf("unexpected signature: %s", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -13,42 +14,80 @@ import (
 	"github.com/opensourceways/defect-manager/defect/domain/bulletin"
 	"github.com/opensourceways/defect-manager/defect/domain/dp"
 	"github.com/opensourceways/defect-manager/defect/domain/obs"
+	"github.com/opensourceways/defect-manager/defect/domain/osv"
 	"github.com/opensourceways/defect-manager/defect/domain/producttree"
 	"github.com/opensourceways/defect-manager/defect/domain/repository"
 	"github.com/opensourceways/defect-manager/utils"
 )
 
-const uploadedDefect = "update_defect.txt"
-
 type DefectService interface {
 	IsDefectExist(*domain.Issue) (bool, error)
 	SaveDefects(CmdToSaveDefect) error
 	CollectDefects(time time.Time) ([]CollectDefectsDTO, error)
-	GenerateBulletins([]string) error
+	CreateBulletinJob(CmdToGenerateBulletins) (BulletinJobDTO, error)
+	GenerateBulletins(string, CmdToGenerateBulletins) error
+	GetBulletinJob(string) (BulletinJobDTO, error)
+	PreviewBulletins(CmdToGenerateBulletins) ([]BulletinPreviewDTO, error)
+	FindBulletins(CmdToFindBulletins) ([]BulletinDTO, error)
+	UpdateBulletin(CmdToUpdateBulletin) error
+	WithdrawBulletin(CmdToWithdrawBulletin) error
+	GetBulletinDocument(CmdToGetBulletinDocument) (BulletinDocumentDTO, error)
+	GetSigningKey() (SigningKeyDTO, error)
+	ExportOSV(time time.Time) ([]OsvDTO, error)
 }
 
 func NewDefectService(
 	r repository.DefectRepository,
+	br repository.BulletinRepository,
+	s repository.BulletinSequence,
+	j repository.BulletinJobRepository,
+	l repository.Lock,
 	t producttree.ProductTree,
 	b bulletin.Bulletin,
+	id bulletin.Identifier,
+	ck bulletin.Checker,
+	sg bulletin.Signer,
+	f []bulletin.Format,
 	be backend.CveBackend,
 	o obs.OBS,
+	ov osv.OSV,
+	c utils.Clock,
 ) *defectService {
 	return &defectService{
-		repo:        r,
-		productTree: t,
-		bulletin:    b,
-		backend:     be,
-		obs:         o,
+		repo:         r,
+		bulletinRepo: br,
+		sequence:     s,
+		jobRepo:      j,
+		lock:         l,
+		productTree:  t,
+		bulletin:     b,
+		identifier:   id,
+		checker:      ck,
+		signer:       sg,
+		formats:      f,
+		backend:      be,
+		obs:          o,
+		osv:          ov,
+		clock:        c,
 	}
 }
 
 type defectService struct {
-	repo        repository.DefectRepository
-	productTree producttree.ProductTree
-	bulletin    bulletin.Bulletin
-	backend     backend.CveBackend
-	obs         obs.OBS
+	repo         repository.DefectRepository
+	bulletinRepo repository.BulletinRepository
+	sequence     repository.BulletinSequence
+	jobRepo      repository.BulletinJobRepository
+	lock         repository.Lock
+	productTree  producttree.ProductTree
+	bulletin     bulletin.Bulletin
+	identifier   bulletin.Identifier
+	checker      bulletin.Checker
+	signer       bulletin.Signer
+	formats      []bulletin.Format
+	backend      backend.CveBackend
+	obs          obs.OBS
+	osv          osv.OSV
+	clock        utils.Clock
 }
 
 func (d defectService) IsDefectExist(issue *domain.Issue) (bool, error) {
@@ -84,8 +123,14 @@ func (d defectService) CollectDefects(date time.Time) (dto []CollectDefectsDTO,
 		return
 	}
 
+	// the defects of withdrawn bulletins are published again
+	withdrawnNum, err := d.bulletinRepo.FindWithdrawnDefects()
+	if err != nil {
+		return
+	}
+
 	var unpublishedDefects domain.Defects
-	ps := sets.NewString(publishedNum...)
+	ps := sets.NewString(publishedNum...).Delete(withdrawnNum...)
 	for _, defect := range defects {
 		if _, ok := ps[defect.Issue.Number]; !ok {
 			unpublishedDefects = append(unpublishedDefects, defect)
@@ -97,68 +142,506 @@ func (d defectService) CollectDefects(date time.Time) (dto []CollectDefectsDTO,
 	return
 }
 
-func (d defectService) GenerateBulletins(number []string) error {
+// ExportOSV exports the accepted defects after the date as OSV records
+func (d defectService) ExportOSV(date time.Time) ([]OsvDTO, error) {
 	opt := repository.OptToFindDefects{
-		Number: number,
+		BeginTime: date,
+		Status:    dp.IssueStatusClosed,
 	}
 
 	defects, err := d.repo.FindDefects(opt)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	dto := make([]OsvDTO, len(defects))
+	for k := range defects {
+		if dto[k], err = d.osv.Generate(&defects[k]); err != nil {
+			return nil, fmt.Errorf("generate osv of %s error: %s", defects[k].Issue.Number, err.Error())
+		}
+	}
+
+	return dto, nil
+}
+
+func (d defectService) CreateBulletinJob(cmd CmdToGenerateBulletins) (dto BulletinJobDTO, err error) {
+	job := domain.BulletinJob{
+		Id:          uuid.NewString(),
+		IssueNumber: cmd.IssueNumber,
+		Status:      dp.JobStatusRunning,
+		CreatedAt:   d.clock.Now().Unix(),
 	}
 
-	maxIdentification, err := d.backend.MaxBulletinID()
+	if err = d.jobRepo.AddJob(&job); err != nil {
+		return
+	}
+
+	return ToBulletinJobDTO(&job), nil
+}
+
+func (d defectService) GetBulletinJob(id string) (dto BulletinJobDTO, err error) {
+	job, err := d.jobRepo.FindJob(id)
+	if err != nil {
+		return
+	}
+
+	return ToBulletinJobDTO(&job), nil
+}
+
+// GenerateBulletins generates bulletins of the job, the result of each bulletin is recorded in the job
+func (d defectService) GenerateBulletins(jobId string, cmd CmdToGenerateBulletins) error {
+	job, err := d.jobRepo.FindJob(jobId)
 	if err != nil {
 		return err
 	}
 
-	bulletins := defects.GenerateBulletins()
+	err = d.generateBulletins(&job, cmd)
+
+	job.Finish(err)
+	d.saveJob(&job)
+
+	return err
+}
+
+// PreviewBulletins runs the whole pipeline of generating bulletins without uploading them
+// and allocating identifications, so that the bulletins can be checked before publishing.
+func (d defectService) PreviewBulletins(cmd CmdToGenerateBulletins) ([]BulletinPreviewDTO, error) {
+	bulletins, err := d.securityBulletins(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	d.productTree.InitCache()
+	defer d.productTree.CleanCache()
+
+	year := d.clock.Now().Year()
+
+	dto := make([]BulletinPreviewDTO, len(bulletins))
+	for k := range bulletins {
+		b := &bulletins[k]
+
+		xmlData, err := d.previewBulletin(b, year, k+1)
+		dto[k] = toBulletinPreviewDTO(b, xmlData, err)
+	}
+
+	return dto, nil
+}
+
+func (d defectService) securityBulletins(cmd CmdToGenerateBulletins) ([]domain.SecurityBulletin, error) {
+	opt := repository.OptToFindDefects{
+		Number: cmd.IssueNumber,
+	}
+
+	defects, err := d.repo.FindDefects(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return defects.GenerateBulletins(cmd.Policy, d.date())
+}
+
+func (d defectService) date() string {
+	return utils.ToDate(d.clock.Now().Unix())
+}
+
+func (d defectService) generateBulletins(job *domain.BulletinJob, cmd CmdToGenerateBulletins) error {
+	bulletins, err := d.securityBulletins(cmd)
+	if err != nil {
+		return err
+	}
 
 	d.productTree.InitCache()
 	defer d.productTree.CleanCache()
 
+	year := d.clock.Now().Year()
+
 	var uploadedFile []string
-	for _, b := range bulletins {
-		maxIdentification++
-		b.Identification = fmt.Sprintf("cvrf-openEuler-BA-%d-%d", utils.Year(), maxIdentification)
+	manifest := new(checksumManifest)
+	for k, b := range bulletins {
+		job.Items = append(job.Items, domain.NewBulletinJobItem(&b))
+		item := &job.Items[len(job.Items)-1]
+
+		var filePath string
+		if cmd.DryRun {
+			_, err = d.previewBulletin(&b, year, k+1)
+			item.Identification = b.Identification
+		} else {
+			filePath, err = d.generateBulletin(&b, item, year, manifest)
+		}
 
-		b.ProductTree, err = d.productTree.GetTree(b.Component, b.AffectedVersion)
 		if err != nil {
-			logrus.Errorf("%s, component %s, get productTree error: %s", b.Identification, b.Component, err.Error())
+			logrus.Errorf("%s, component: %s, %s", b.Identification, b.Component, err.Error())
 
-			continue
+			item.Fail(err)
+		} else {
+			item.Succeed()
+		}
+
+		if filePath != "" {
+			uploadedFile = append(uploadedFile, filePath)
+		}
+
+		d.saveJob(job)
+	}
+
+	if err = d.uploadManifest(job.Id, manifest); err != nil {
+		return err
+	}
+
+	return d.updateIndex(uploadedFile)
+}
+
+// previewBulletin generates the xml of bulletin with a placeholder identification, and checks it
+// as the publishing does, so that the bulletin which can not be published is reported.
+func (d defectService) previewBulletin(b *domain.SecurityBulletin, year, index int) ([]byte, error) {
+	b.Identification = d.identifier.Preview(year, index)
+
+	xmlData, err := d.renderBulletin(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = d.checker.CheckPreview(xmlData); err != nil {
+		return xmlData, fmt.Errorf("invalid bulletin: %s", err.Error())
+	}
+
+	return xmlData, nil
+}
+
+// generateBulletin allocates identification for the bulletin, generates and uploads it.
+// The path of cvrf file is returned once it is uploaded, even if the other formats fail.
+func (d defectService) generateBulletin(
+	b *domain.SecurityBulletin, item *domain.BulletinJobItem, year int, m *checksumManifest,
+) (filePath string, err error) {
+	num, err := d.sequence.Allocate(year, d.identifier.StartNumber())
+	if err != nil {
+		err = fmt.Errorf("allocate bulletin id error: %s", err.Error())
+
+		return
+	}
+
+	b.Identification = d.identifier.Identification(year, num)
+
+	record, err := d.buildBulletin(b)
+	if err != nil {
+		d.releaseBulletinNum(year, num)
+
+		return
+	}
+
+	item.Identification = b.Identification
+
+	p := d.filePath(b.Identification + ".xml")
+	if err = d.uploadDocument(p, record.Xml, m); err != nil {
+		err = fmt.Errorf("upload to obs error: %s", err.Error())
+
+		d.saveUploadStatus(&record, dp.UploadStatusFailed)
+
+		return
+	}
+
+	d.saveUploadStatus(&record, dp.UploadStatusSucceed)
+
+	return p, d.uploadExtraFormats(b, m)
+}
+
+// uploadExtraFormats uploads the other formats of bulletin and the OSV records of its defects
+func (d defectService) uploadExtraFormats(b *domain.SecurityBulletin, m *checksumManifest) error {
+	var failed []string
+	for _, f := range d.formats {
+		data, err := f.Bulletin.Generate(b)
+		if err == nil {
+			err = d.uploadDocument(d.filePath(b.Identification+f.Extension), data, m)
 		}
 
-		xmlData, err := d.bulletin.Generate(&b)
 		if err != nil {
-			logrus.Errorf("%s, component: %s, to xml error: %s", b.Identification, b.Component, err.Error())
+			failed = append(failed, fmt.Sprintf("%s: %s", f.Name, err.Error()))
+		}
+	}
 
-			continue
+	for k := range b.Defects {
+		if err := d.uploadOSV(&b.Defects[k], m); err != nil {
+			failed = append(failed, fmt.Sprintf("osv of %s: %s", b.Defects[k].Issue.Number, err.Error()))
 		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("upload formats error: %s", strings.Join(failed, "; "))
+	}
+
+	return nil
+}
+
+func (d defectService) uploadOSV(defect *domain.Defect, m *checksumManifest) error {
+	data, err := d.osv.Generate(defect)
+	if err != nil {
+		return err
+	}
 
-		fileName := fmt.Sprintf("%s.xml", b.Identification)
-		if err := d.obs.Upload(fileName, xmlData); err != nil {
-			logrus.Errorf("%s, component: %s, upload to obs error: %s", b.Identification, b.Component, err.Error())
+	return d.uploadDocument(d.filePath(d.osv.Identification(defect)+".json"), data, m)
+}
 
+func (d defectService) saveJob(job *domain.BulletinJob) {
+	if err := d.jobRepo.SaveJob(job); err != nil {
+		logrus.Errorf("save bulletin job %s error: %s", job.Id, err.Error())
+	}
+}
+
+// buildBulletin generates the xml of bulletin and saves it, the identification
+// can be released only when it fails, because it is not used by any bulletin then.
+func (d defectService) buildBulletin(b *domain.SecurityBulletin) (record domain.BulletinRecord, err error) {
+	xmlData, err := d.renderCheckedBulletin(b)
+	if err != nil {
+		return
+	}
+
+	record = domain.BulletinRecord{
+		Bulletin:     *b,
+		Xml:          xmlData,
+		UploadStatus: dp.UploadStatusPending,
+	}
+	if err = d.bulletinRepo.AddBulletin(&record); err != nil {
+		err = fmt.Errorf("save bulletin error: %s", err.Error())
+	}
+
+	return
+}
+
+// renderBulletin fills the product tree of bulletin and generates the xml of it
+func (d defectService) renderBulletin(b *domain.SecurityBulletin) (xmlData []byte, err error) {
+	if b.ProductTree, err = d.productTree.GetTree(b.Component, b.AffectedVersion); err != nil {
+		err = fmt.Errorf("get productTree error: %s", err.Error())
+
+		return
+	}
+
+	if xmlData, err = d.bulletin.Generate(b); err != nil {
+		err = fmt.Errorf("to xml error: %s", err.Error())
+	}
+
+	return
+}
+
+// renderCheckedBulletin renders the bulletin which is going to be uploaded,
+// the invalid one must not be published
+func (d defectService) renderCheckedBulletin(b *domain.SecurityBulletin) ([]byte, error) {
+	xmlData, err := d.renderBulletin(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = d.checker.Check(xmlData); err != nil {
+		return nil, fmt.Errorf("invalid bulletin: %s", err.Error())
+	}
+
+	return xmlData, nil
+}
+
+func (d defectService) releaseBulletinNum(year, num int) {
+	if err := d.sequence.Release(year, num); err != nil {
+		logrus.Errorf("release bulletin number %d of %d error: %s", num, year, err.Error())
+	}
+}
+
+// ReconcileBulletinSequence makes sure that the local sequence is not behind
+// the identifications which have been published by the backend
+func (d defectService) ReconcileBulletinSequence() error {
+	latest, err := d.backend.MaxBulletinIdentification()
+	if err != nil {
+		return err
+	}
+
+	year := d.clock.Now().Year()
+
+	// the number restarts at new year
+	maxNum := d.identifier.StartNumber()
+	if latest != "" {
+		y, num, err := d.identifier.Parse(latest)
+		if err != nil {
+			return err
+		}
+
+		if y == year {
+			maxNum = num
+		}
+	}
+
+	return d.sequence.Reconcile(year, maxNum)
+}
+
+const jobInterruptedReason = "the job was interrupted by the restart of service"
+
+// FailInterruptedJobs fails the jobs which are still running when the service starts,
+// they were stopped by the last exit of the service and will never finish.
+func (d defectService) FailInterruptedJobs() error {
+	jobs, err := d.jobRepo.FindJobs(dp.JobStatusRunning)
+	if err != nil {
+		return err
+	}
+
+	for k := range jobs {
+		jobs[k].Interrupt(jobInterruptedReason)
+		d.saveJob(&jobs[k])
+	}
+
+	return nil
+}
+
+func (d defectService) saveUploadStatus(r *domain.BulletinRecord, status dp.UploadStatus) {
+	r.UploadStatus = status
+
+	if err := d.bulletinRepo.SaveBulletin(r); err != nil {
+		logrus.Errorf("%s, save upload status %s error: %s",
+			r.Bulletin.Identification, status.String(), err.Error(),
+		)
+	}
+}
+
+func (d defectService) FindBulletins(cmd CmdToFindBulletins) ([]BulletinDTO, error) {
+	records, err := d.bulletinRepo.FindBulletins(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToBulletinDTO(records), nil
+}
+
+// UpdateBulletin re-issues the bulletin under the same identification with a new revision,
+// the defects and product tree of it are reloaded, so that the corrections of them are published.
+func (d defectService) UpdateBulletin(cmd CmdToUpdateBulletin) error {
+	record, err := d.bulletinRepo.FindBulletin(cmd.Identification)
+	if err != nil {
+		return err
+	}
+
+	if err = record.Bulletin.AddRevision(cmd.Description, d.date()); err != nil {
+		return err
+	}
+
+	return d.publishRevision(&record)
+}
+
+// WithdrawBulletin re-issues the bulletin published in error as withdrawn and adds it to the uploaded file,
+// the defects covered by it can be collected again.
+func (d defectService) WithdrawBulletin(cmd CmdToWithdrawBulletin) error {
+	record, err := d.bulletinRepo.FindBulletin(cmd.Identification)
+	if err != nil {
+		return err
+	}
+
+	if err = record.Bulletin.Withdraw(cmd.Reason, d.date()); err != nil {
+		return err
+	}
+
+	return d.publishRevision(&record)
+}
+
+// GetBulletinDocument returns the bulletin in the format, the cvrf one is the document published
+// and the others are rendered from the bulletin saved.
+func (d defectService) GetBulletinDocument(cmd CmdToGetBulletinDocument) (dto BulletinDocumentDTO, err error) {
+	record, err := d.bulletinRepo.FindBulletin(cmd.Identification)
+	if err != nil {
+		return
+	}
+
+	if cmd.Format == bulletin.FormatCVRF {
+		return BulletinDocumentDTO{ContentType: "application/xml", Data: record.Xml}, nil
+	}
+
+	for _, f := range d.formats {
+		if f.Name != cmd.Format {
 			continue
 		}
 
-		uploadedFile = append(uploadedFile, fileName)
+		b := &record.Bulletin
+		// the product tree is not saved for the bulletins generated before
+		if len(b.ProductTree) == 0 {
+			d.productTree.InitCache()
+			defer d.productTree.CleanCache()
+
+			if b.ProductTree, err = d.productTree.GetTree(b.Component, b.AffectedVersion); err != nil {
+				return
+			}
+		}
+
+		if dto.Data, err = f.Bulletin.Generate(b); err != nil {
+			return
+		}
+
+		dto.ContentType = f.ContentType
+
+		return
 	}
 
-	return d.uploadUploadedFile(uploadedFile)
+	err = ErrUnsupportedFormat
+
+	return
 }
 
-func (d defectService) uploadUploadedFile(files []string) error {
-	if len(files) == 0 {
-		return nil
+// GetSigningKey returns the key which verifies the signatures of the documents uploaded
+func (d defectService) GetSigningKey() (SigningKeyDTO, error) {
+	if d.signer == nil {
+		return SigningKeyDTO{}, ErrSigningDisabled
 	}
 
-	var uploadedFileWithPrefix []string
-	for _, v := range files {
-		t := fmt.Sprintf("%d/%s", time.Now().Year(), v)
-		uploadedFileWithPrefix = append(uploadedFileWithPrefix, t)
+	return SigningKeyDTO{Fingerprint: d.signer.Fingerprint()}, nil
+}
+
+// publishRevision re-issues the bulletin with the other formats of it, the manifest of them
+// is uploaded and the index is updated, as it is done when the bulletins are generated.
+func (d defectService) publishRevision(record *domain.BulletinRecord) error {
+	b := &record.Bulletin
+	manifest := new(checksumManifest)
+
+	p, err := d.reissueBulletin(record, manifest)
+	if err != nil {
+		return err
+	}
+
+	formatErr := d.uploadExtraFormats(b, manifest)
+
+	if err = d.uploadManifest(fmt.Sprintf("%s-%d", b.Identification, b.Version()), manifest); err != nil {
+		return err
 	}
 
-	return d.obs.Upload(uploadedDefect, []byte(strings.Join(uploadedFileWithPrefix, "\n")))
+	if err = d.updateIndex([]string{p}); err != nil {
+		return err
+	}
+
+	return formatErr
+}
+
+// reissueBulletin regenerates the revised bulletin and uploads it under the same identification,
+// the path of it is returned.
+func (d defectService) reissueBulletin(
+	record *domain.BulletinRecord, m *checksumManifest,
+) (filePath string, err error) {
+	b := &record.Bulletin
+
+	d.productTree.InitCache()
+	defer d.productTree.CleanCache()
+
+	if record.Xml, err = d.renderCheckedBulletin(b); err != nil {
+		return
+	}
+
+	record.UploadStatus = dp.UploadStatusPending
+	if err = d.bulletinRepo.SaveBulletin(record); err != nil {
+		err = fmt.Errorf("save bulletin error: %s", err.Error())
+
+		return
+	}
+
+	filePath = d.filePath(b.Identification + ".xml")
+	if err = d.uploadDocument(filePath, record.Xml, m); err != nil {
+		d.saveUploadStatus(record, dp.UploadStatusFailed)
+
+		err = fmt.Errorf("upload to obs error: %s", err.Error())
+
+		return
+	}
+
+	d.saveUploadStatus(record, dp.UploadStatusSucceed)
+
+	return
 }
@@ -1,9 +1,11 @@
 package app
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/repository"
 )
 
 const (
@@ -40,3 +42,164 @@ func ToCollectDefectsDTO(defects domain.Defects) []CollectDefectsDTO {
 
 	return dto
 }
+
+type CmdToFindBulletins = repository.OptToFindBulletins
+
+type BulletinDTO struct {
+	Identification  string   `json:"identification"`
+	Component       string   `json:"component"`
+	AffectedVersion []string `json:"affected_version"`
+	Date            string   `json:"date"`
+	Version         int      `json:"version"`
+	Withdrawn       bool     `json:"withdrawn"`
+	UploadStatus    string   `json:"upload_status"`
+	IssueNumber     []string `json:"issue_number"`
+}
+
+func ToBulletinDTO(records []domain.BulletinRecord) []BulletinDTO {
+	var dto []BulletinDTO
+	for _, r := range records {
+		var versions []string
+		for _, v := range r.Bulletin.AffectedVersion {
+			versions = append(versions, v.String())
+		}
+
+		var numbers []string
+		for _, d := range r.Bulletin.Defects {
+			numbers = append(numbers, d.Issue.Number)
+		}
+
+		dto = append(dto, BulletinDTO{
+			Identification:  r.Bulletin.Identification,
+			Component:       r.Bulletin.Component,
+			AffectedVersion: versions,
+			Date:            r.Bulletin.Date,
+			Version:         r.Bulletin.Version(),
+			Withdrawn:       r.Bulletin.Withdrawn,
+			UploadStatus:    r.UploadStatus.String(),
+			IssueNumber:     numbers,
+		})
+	}
+
+	return dto
+}
+
+type CmdToUpdateBulletin struct {
+	Identification string
+	Description    string
+}
+
+type CmdToWithdrawBulletin struct {
+	Identification string
+	Reason         string
+}
+
+type CmdToGetBulletinDocument struct {
+	Identification string
+	Format         string
+}
+
+// BulletinDocumentDTO is the bulletin rendered in one of the formats
+type BulletinDocumentDTO struct {
+	ContentType string
+	Data        []byte
+}
+
+// SigningKeyDTO is the key which verifies the signatures of the documents of bulletin
+type SigningKeyDTO struct {
+	Fingerprint string `json:"fingerprint"`
+}
+
+type CmdToGenerateBulletins struct {
+	IssueNumber []string
+	DryRun      bool
+	Policy      domain.GroupingPolicy
+}
+
+type BulletinJobItemDTO struct {
+	Identification  string   `json:"identification"`
+	Component       string   `json:"component"`
+	AffectedVersion []string `json:"affected_version"`
+	IssueNumber     []string `json:"issue_number"`
+	Status          string   `json:"status"`
+	Error           string   `json:"error,omitempty"`
+}
+
+type BulletinJobDTO struct {
+	Id          string               `json:"id"`
+	IssueNumber []string             `json:"issue_number"`
+	Status      string               `json:"status"`
+	Error       string               `json:"error,omitempty"`
+	Items       []BulletinJobItemDTO `json:"items"`
+	CreatedAt   int64                `json:"created_at"`
+	UpdatedAt   int64                `json:"updated_at"`
+}
+
+func ToBulletinJobDTO(job *domain.BulletinJob) BulletinJobDTO {
+	items := make([]BulletinJobItemDTO, len(job.Items))
+	for k, v := range job.Items {
+		var versions []string
+		for _, version := range v.AffectedVersion {
+			versions = append(versions, version.String())
+		}
+
+		items[k] = BulletinJobItemDTO{
+			Identification:  v.Identification,
+			Component:       v.Component,
+			AffectedVersion: versions,
+			IssueNumber:     v.IssueNumber,
+			Status:          v.Status.String(),
+			Error:           v.Error,
+		}
+	}
+
+	return BulletinJobDTO{
+		Id:          job.Id,
+		IssueNumber: job.IssueNumber,
+		Status:      job.Status.String(),
+		Error:       job.Error,
+		Items:       items,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+	}
+}
+
+type BulletinPreviewDTO struct {
+	Identification  string   `json:"identification"`
+	Component       string   `json:"component"`
+	AffectedVersion []string `json:"affected_version"`
+	IssueNumber     []string `json:"issue_number"`
+	Grouping        string   `json:"grouping"`
+	Xml             string   `json:"xml,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+func toBulletinPreviewDTO(sb *domain.SecurityBulletin, xmlData []byte, err error) BulletinPreviewDTO {
+	var versions []string
+	for _, v := range sb.AffectedVersion {
+		versions = append(versions, v.String())
+	}
+
+	var numbers []string
+	for _, d := range sb.Defects {
+		numbers = append(numbers, d.Issue.Number)
+	}
+
+	dto := BulletinPreviewDTO{
+		Identification:  sb.Identification,
+		Component:       sb.Component,
+		AffectedVersion: versions,
+		IssueNumber:     numbers,
+		Grouping:        sb.Grouping,
+		Xml:             string(xmlData),
+	}
+
+	if err != nil {
+		dto.Error = err.Error()
+	}
+
+	return dto
+}
+
+// OsvDTO is the OSV record of a defect in json
+type OsvDTO = json.RawMessage
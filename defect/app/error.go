@@ -0,0 +1,20 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/repository"
+)
+
+// ErrNotFound is returned when the resource requested does not exist
+var ErrNotFound = repository.ErrNotFound
+
+// ErrBulletinWithdrawn is returned when the bulletin to withdraw has been withdrawn
+var ErrBulletinWithdrawn = domain.ErrBulletinWithdrawn
+
+// ErrSigningDisabled is returned when the signing key is requested but the documents are not signed
+var ErrSigningDisabled = errors.New("signing is disabled")
+
+// ErrUnsupportedFormat is returned when the bulletin is requested in a format which is not configured
+var ErrUnsupportedFormat = errors.New("unsupported format")
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opensourceways/defect-manager/defect/domain/obs"
+)
+
+const (
+	uploadedDefect = "update_defect.txt"
+	indexFile      = "index.txt"
+	// bulletinListFile lists all the bulletins in cvrf as the index of cvrf does, one line of each bulletin,
+	// newest first, such as:
+	//
+	//	"cvrf-openEuler-BA-2024-1002","2024-03-01/cvrf-openEuler-BA-2024-1002.xml"
+	//	"cvrf-openEuler-BA-2024-1001","2024-02-29/cvrf-openEuler-BA-2024-1001.xml"
+	//
+	// the first field is the identification of bulletin, the second one is the path of cvrf in the storage.
+	bulletinListFile = "bulletins.csv"
+
+	// indexLock serializes the updates of index among all the instances of service,
+	// because they read the index and write it back
+	indexLock = "bulletin-index"
+	// indexLockTimeout bounds both the wait and the holding of indexLock
+	indexLockTimeout = time.Minute
+)
+
+// filePath is the path of the file uploaded today
+func (d defectService) filePath(name string) string {
+	return path.Join(d.date(), name)
+}
+
+// updateIndex adds the bulletins uploaded to the uploaded file of today, and regenerates the index
+// of all the bulletins by the files in the storage, so that the index is consistent with the storage.
+// Only the read-merge-write of the index is done under the lock, the listing of storage is done before
+// it and the files are committed after it if the storage publishes them by commit.
+func (d defectService) updateIndex(paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	files, err := d.obs.List()
+	if err != nil {
+		return fmt.Errorf("list files error: %s", err.Error())
+	}
+
+	err = d.lock.Do(indexLock, indexLockTimeout, func() error {
+		return d.mergeIndex(d.storagePaths(paths), files)
+	})
+	if err != nil {
+		return err
+	}
+
+	if c, ok := d.obs.(obs.Committer); ok {
+		if err = c.Commit(paths); err != nil {
+			return fmt.Errorf("commit error: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// storagePaths maps the paths uploaded to the ones in the storage, the files listed
+// by the index must be found by the paths which the storage lists.
+func (d defectService) storagePaths(paths []string) []string {
+	l, ok := d.obs.(obs.Layout)
+	if !ok {
+		return paths
+	}
+
+	mapped := make([]string, len(paths))
+	for k, v := range paths {
+		mapped[k] = l.StoragePath(v)
+	}
+
+	return mapped
+}
+
+// mergeIndex merges the paths stored to the uploaded file of today and writes the index back.
+// The files listed before the lock may miss the ones which other instances uploaded meanwhile,
+// they are all in the uploaded file of today, so the index is made of the both.
+func (d defectService) mergeIndex(stored, files []string) error {
+	uploaded := d.filePath(uploadedDefect)
+
+	data, err := d.obs.Download(uploaded)
+	if err != nil && !errors.Is(err, obs.ErrNotFound) {
+		return fmt.Errorf("download %s error: %s", uploaded, err.Error())
+	}
+
+	merged := mergeLines(data, stored)
+	if err = d.obs.Upload(uploaded, merged); err != nil {
+		return fmt.Errorf("upload %s error: %s", uploaded, err.Error())
+	}
+
+	index := newBulletinIndex(append(files, strings.Split(string(merged), "\n")...))
+
+	if err = d.obs.Upload(indexFile, index.index()); err != nil {
+		return fmt.Errorf("upload %s error: %s", indexFile, err.Error())
+	}
+
+	if err = d.obs.Upload(bulletinListFile, index.bulletins()); err != nil {
+		return fmt.Errorf("upload %s error: %s", bulletinListFile, err.Error())
+	}
+
+	return nil
+}
+
+// mergeLines appends the lines which are not in the data
+func mergeLines(data []byte, lines []string) []byte {
+	var merged []string
+	existing := map[string]bool{}
+
+	for _, v := range append(strings.Split(string(data), "\n"), lines...) {
+		if v = strings.TrimSpace(v); v != "" && !existing[v] {
+			existing[v] = true
+			merged = append(merged, v)
+		}
+	}
+
+	return []byte(strings.Join(merged, "\n"))
+}
+
+// bulletinIndex keeps the latest path of each bulletin, the paths are under the directory of date,
+// so that the one of a later date is uploaded lately.
+type bulletinIndex map[string]string
+
+func newBulletinIndex(files []string) bulletinIndex {
+	entries := bulletinIndex{}
+
+	for _, v := range files {
+		if path.Ext(v) != ".xml" {
+			continue
+		}
+
+		name := path.Base(v)
+		if p, ok := entries[name]; !ok || p < v {
+			entries[name] = v
+		}
+	}
+
+	return entries
+}
+
+func (entries bulletinIndex) paths() []string {
+	paths := make([]string, 0, len(entries))
+	for _, v := range entries {
+		paths = append(paths, v)
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+func (entries bulletinIndex) index() []byte {
+	return []byte(strings.Join(entries.paths(), "\n"))
+}
+
+// bulletins is the content of bulletinListFile
+func (entries bulletinIndex) bulletins() []byte {
+	paths := entries.paths()
+
+	lines := make([]string, len(paths))
+	for k, v := range paths {
+		lines[len(paths)-1-k] = fmt.Sprintf("%q,%q", strings.TrimSuffix(path.Base(v), ".xml"), v)
+	}
+
+	return []byte(strings.Join(lines, "\n"))
+}
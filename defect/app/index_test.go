@@ -0,0 +1,234 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/opensourceways/defect-manager/defect/domain/obs"
+)
+
+type storageTest map[string]string
+
+func (s storageTest) Upload(path string, data []byte) error {
+	s[path] = string(data)
+
+	return nil
+}
+
+func (s storageTest) Download(path string) ([]byte, error) {
+	v, ok := s[path]
+	if !ok {
+		return nil, obs.ErrNotFound
+	}
+
+	return []byte(v), nil
+}
+
+func (s storageTest) List() ([]string, error) {
+	var paths []string
+	for k := range s {
+		paths = append(paths, k)
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// lockTest records the names locked, the functions run one by one in the tests
+type lockTest []string
+
+func (l *lockTest) Do(name string, timeout time.Duration, f func() error) error {
+	if timeout <= 0 {
+		return fmt.Errorf("lock %s without timeout", name)
+	}
+
+	*l = append(*l, name)
+
+	return f()
+}
+
+type clockTest struct {
+	now time.Time
+}
+
+func (c *clockTest) Now() time.Time {
+	return c.now
+}
+
+func TestUpdateIndex(t *testing.T) {
+	storage := storageTest{
+		"2024-02-28/cvrf-openEuler-BA-2024-1001.xml":  "",
+		"2024-02-28/cvrf-openEuler-BA-2024-1001.json": "",
+		// not in the index, but in the storage
+		"2024-02-29/cvrf-openEuler-BA-2024-1002.xml": "",
+		indexFile: "2024-02-28/cvrf-openEuler-BA-2024-1001.xml\n2024-02-28/cvrf-openEuler-BA-2024-1000.xml",
+	}
+
+	clock := &clockTest{now: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)}
+	lock := new(lockTest)
+	d := defectService{obs: storage, clock: clock, lock: lock}
+
+	runs := [][]string{
+		{"cvrf-openEuler-BA-2024-1003.xml"},
+		{"cvrf-openEuler-BA-2024-1004.xml", "cvrf-openEuler-BA-2024-1003.xml"},
+		// the bulletin is updated
+		{"cvrf-openEuler-BA-2024-1001.xml"},
+	}
+	for _, files := range runs {
+		var paths []string
+		for _, v := range files {
+			p := d.filePath(v)
+			storage[p] = ""
+			paths = append(paths, p)
+		}
+
+		if err := d.updateIndex(paths); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{
+			path: "2024-03-01/" + uploadedDefect,
+			want: "2024-03-01/cvrf-openEuler-BA-2024-1003.xml\n" +
+				"2024-03-01/cvrf-openEuler-BA-2024-1004.xml\n" +
+				"2024-03-01/cvrf-openEuler-BA-2024-1001.xml",
+		},
+		{
+			path: indexFile,
+			want: "2024-02-29/cvrf-openEuler-BA-2024-1002.xml\n" +
+				"2024-03-01/cvrf-openEuler-BA-2024-1001.xml\n" +
+				"2024-03-01/cvrf-openEuler-BA-2024-1003.xml\n" +
+				"2024-03-01/cvrf-openEuler-BA-2024-1004.xml",
+		},
+		{
+			path: bulletinListFile,
+			want: `"cvrf-openEuler-BA-2024-1004","2024-03-01/cvrf-openEuler-BA-2024-1004.xml"` + "\n" +
+				`"cvrf-openEuler-BA-2024-1003","2024-03-01/cvrf-openEuler-BA-2024-1003.xml"` + "\n" +
+				`"cvrf-openEuler-BA-2024-1001","2024-03-01/cvrf-openEuler-BA-2024-1001.xml"` + "\n" +
+				`"cvrf-openEuler-BA-2024-1002","2024-02-29/cvrf-openEuler-BA-2024-1002.xml"`,
+		},
+	}
+	for _, tt := range tests {
+		if got := storage[tt.path]; got != tt.want {
+			t.Errorf("%s:\nwant:\n%s\ngot:\n%s", tt.path, tt.want, got)
+		}
+	}
+
+	if len(*lock) != len(runs) || (*lock)[0] != indexLock {
+		t.Errorf("the index is not updated under the lock: %v", *lock)
+	}
+}
+
+// yearStorageTest stores the files of a date under the directory of the year as the git repository does
+type yearStorageTest struct {
+	storageTest
+}
+
+func (s yearStorageTest) StoragePath(p string) string {
+	if len(p) > 10 && p[10] == '/' {
+		return p[:4] + p[10:]
+	}
+
+	return p
+}
+
+func (s yearStorageTest) Upload(path string, data []byte) error {
+	return s.storageTest.Upload(s.StoragePath(path), data)
+}
+
+func (s yearStorageTest) Download(path string) ([]byte, error) {
+	return s.storageTest.Download(s.StoragePath(path))
+}
+
+func TestUpdateIndexOfLayout(t *testing.T) {
+	storage := yearStorageTest{storageTest{"2023/cvrf-openEuler-BA-2023-1001.xml": ""}}
+
+	clock := &clockTest{now: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)}
+	d := defectService{obs: storage, clock: clock, lock: new(lockTest)}
+
+	p := d.filePath("cvrf-openEuler-BA-2024-1001.xml")
+	if err := storage.Upload(p, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.updateIndex([]string{p}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{
+			path: "2024/" + uploadedDefect,
+			want: "2024/cvrf-openEuler-BA-2024-1001.xml",
+		},
+		{
+			path: indexFile,
+			want: "2023/cvrf-openEuler-BA-2023-1001.xml\n2024/cvrf-openEuler-BA-2024-1001.xml",
+		},
+	}
+	for _, tt := range tests {
+		if got := storage.storageTest[tt.path]; got != tt.want {
+			t.Errorf("%s:\nwant:\n%s\ngot:\n%s", tt.path, tt.want, got)
+		}
+	}
+
+	want := `"cvrf-openEuler-BA-2024-1001","2024/cvrf-openEuler-BA-2024-1001.xml"` + "\n" +
+		`"cvrf-openEuler-BA-2023-1001","2023/cvrf-openEuler-BA-2023-1001.xml"`
+	if got := storage.storageTest[bulletinListFile]; got != want {
+		t.Errorf("%s:\nwant:\n%s\ngot:\n%s", bulletinListFile, want, got)
+	}
+}
+
+// uploadingLock uploads a bulletin as another instance does after the storage is listed
+// and before the lock is got
+type uploadingLock struct {
+	lockTest
+	d    *defectService
+	name string
+}
+
+func (l *uploadingLock) Do(name string, timeout time.Duration, f func() error) error {
+	return l.lockTest.Do(name, timeout, func() error {
+		p := l.d.filePath(l.name)
+		if err := l.d.obs.Upload(p, nil); err != nil {
+			return err
+		}
+
+		if err := l.d.mergeIndex([]string{p}, nil); err != nil {
+			return err
+		}
+
+		return f()
+	})
+}
+
+func TestUpdateIndexOfConcurrentUpload(t *testing.T) {
+	storage := storageTest{"2024-02-29/cvrf-openEuler-BA-2024-1001.xml": ""}
+
+	clock := &clockTest{now: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)}
+	d := defectService{obs: storage, clock: clock}
+	d.lock = &uploadingLock{d: &d, name: "cvrf-openEuler-BA-2024-1002.xml"}
+
+	p := d.filePath("cvrf-openEuler-BA-2024-1003.xml")
+	storage[p] = ""
+
+	if err := d.updateIndex([]string{p}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "2024-02-29/cvrf-openEuler-BA-2024-1001.xml\n" +
+		"2024-03-01/cvrf-openEuler-BA-2024-1002.xml\n" +
+		"2024-03-01/cvrf-openEuler-BA-2024-1003.xml"
+	if got := storage[indexFile]; got != want {
+		t.Errorf("the bulletin uploaded concurrently is lost:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
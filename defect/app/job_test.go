@@ -0,0 +1,90 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+	"github.com/opensourceways/defect-manager/defect/domain/repository"
+)
+
+type jobRepoTest map[string]domain.BulletinJob
+
+func (r jobRepoTest) AddJob(job *domain.BulletinJob) error {
+	r[job.Id] = *job
+
+	return nil
+}
+
+func (r jobRepoTest) SaveJob(job *domain.BulletinJob) error {
+	return r.AddJob(job)
+}
+
+func (r jobRepoTest) FindJob(id string) (domain.BulletinJob, error) {
+	job, ok := r[id]
+	if !ok {
+		return job, repository.ErrNotFound
+	}
+
+	return job, nil
+}
+
+func (r jobRepoTest) FindJobs(status dp.JobStatus) ([]domain.BulletinJob, error) {
+	var jobs []domain.BulletinJob
+	for _, job := range r {
+		if job.Status == status {
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs, nil
+}
+
+func TestFailInterruptedJobs(t *testing.T) {
+	succeeded := domain.BulletinJobItem{Identification: "openEuler-BA-2024-1001"}
+	succeeded.Succeed()
+
+	failed := domain.BulletinJobItem{Identification: "openEuler-BA-2024-1002"}
+	failed.Fail(errors.New("upload error"))
+
+	repo := jobRepoTest{
+		"running": {
+			Id:     "running",
+			Status: dp.JobStatusRunning,
+			Items: []domain.BulletinJobItem{
+				succeeded, failed,
+				{Identification: "openEuler-BA-2024-1003", Status: dp.JobStatusRunning},
+			},
+		},
+		"succeeded": {Id: "succeeded", Status: dp.JobStatusSucceeded},
+	}
+
+	d := defectService{jobRepo: repo}
+	if err := d.FailInterruptedJobs(); err != nil {
+		t.Fatal(err)
+	}
+
+	job := repo["running"]
+	if job.Status != dp.JobStatusFailed || job.Error != jobInterruptedReason {
+		t.Errorf("the running job is not failed: %s, %s", job.Status, job.Error)
+	}
+
+	wants := []struct {
+		status dp.JobStatus
+		err    string
+	}{
+		{dp.JobStatusSucceeded, ""},
+		{dp.JobStatusFailed, "upload error"},
+		{dp.JobStatusFailed, jobInterruptedReason},
+	}
+	for k, want := range wants {
+		if item := job.Items[k]; item.Status != want.status || item.Error != want.err {
+			t.Errorf("item %d: want %s %q, got %s %q", k, want.status, want.err, item.Status, item.Error)
+		}
+	}
+
+	if job := repo["succeeded"]; job.Status != dp.JobStatusSucceeded || job.Error != "" {
+		t.Errorf("the finished job is changed: %s, %s", job.Status, job.Error)
+	}
+}
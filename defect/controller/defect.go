@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"errors"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -9,6 +11,12 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/opensourceways/defect-manager/defect/app"
+	"github.com/opensourceways/defect-manager/defect/domain/bulletin"
+)
+
+const (
+	errorNotFound          = "not_found"
+	errorBulletinWithdrawn = "bulletin_withdrawn"
 )
 
 type DefectController struct {
@@ -22,6 +30,14 @@ func AddRouteForDefectController(r *gin.RouterGroup, s app.DefectService) {
 
 	r.GET("/v1/defect", ctl.Collect)
 	r.POST("/v1/defect/bulletin", ctl.GenerateBulletin)
+	r.GET("/v1/defect/bulletin", ctl.ListBulletin)
+	r.PUT("/v1/defect/bulletin/:id", ctl.UpdateBulletin)
+	r.POST("/v1/defect/bulletin/:id/withdrawal", ctl.WithdrawBulletin)
+	r.GET("/v1/defect/bulletin/:id/document", ctl.GetBulletinDocument)
+	r.GET("/v1/defect/bulletin/jobs/:id", ctl.GetBulletinJob)
+	r.GET("/v1/defect/bulletin/signing-key", ctl.GetSigningKey)
+	r.POST("/v1/defect/bulletin/preview", ctl.PreviewBulletin)
+	r.GET("/v1/defect/osv", ctl.ExportOSV)
 }
 
 // Collect
@@ -50,11 +66,11 @@ func (ctl DefectController) Collect(ctx *gin.Context) {
 
 // GenerateBulletin
 // @Summary generate security bulletin for some defects
-// @Description generate security bulletin for some defects
+// @Description generate security bulletin for some defects asynchronously, the progress can be got by the job
 // @Tags  Defect
 // @Accept json
 // @Param	param  body	 bulletinRequest	 true	"body of some issue number"
-// @Success 201 {object} string
+// @Success 201 {object} app.BulletinJobDTO
 // @Failure 400 {object} string
 // @Router /v1/defect/bulletin [post]
 func (ctl DefectController) GenerateBulletin(ctx *gin.Context) {
@@ -65,15 +81,242 @@ func (ctl DefectController) GenerateBulletin(ctx *gin.Context) {
 		return
 	}
 
+	cmd, err := req.toCmd()
+	if err != nil {
+		controller.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	job, err := ctl.service.CreateBulletinJob(cmd)
+	if err != nil {
+		controller.SendFailedResp(ctx, "", err)
+
+		return
+	}
+
 	go func() {
-		logrus.Infof("generate bulletin processing of %v", req.IssueNumber)
+		logrus.Infof("generate bulletin processing of %v, job %s", req.IssueNumber, job.Id)
 
-		if err := ctl.service.GenerateBulletins(req.IssueNumber); err != nil {
+		if err := ctl.service.GenerateBulletins(job.Id, cmd); err != nil {
 			logrus.Errorf("generate bulletin of %v err: %s", req.IssueNumber, err.Error())
 		} else {
 			logrus.Infof("generate bulletin success of %v", req.IssueNumber)
 		}
 	}()
 
-	controller.SendRespOfPost(ctx, "Processing: Data is being prepared, please wait patiently\n")
+	controller.SendRespOfPost(ctx, job)
+}
+
+// PreviewBulletin
+// @Summary preview security bulletin for some defects
+// @Description generate security bulletin for some defects without uploading it and allocating identification
+// @Tags  Defect
+// @Accept json
+// @Param	param  body	 bulletinRequest	 true	"body of some issue number"
+// @Success 201 {object} []app.BulletinPreviewDTO
+// @Failure 400 {object} string
+// @Router /v1/defect/bulletin/preview [post]
+func (ctl DefectController) PreviewBulletin(ctx *gin.Context) {
+	var req bulletinRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		controller.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		controller.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if v, err := ctl.service.PreviewBulletins(cmd); err != nil {
+		controller.SendFailedResp(ctx, "", err)
+	} else {
+		controller.SendRespOfPost(ctx, v)
+	}
+}
+
+// GetBulletinJob
+// @Summary get the job of generating security bulletins
+// @Description get the job of generating security bulletins, including the result of each bulletin
+// @Tags  Defect
+// @Accept json
+// @Param	id  path string	 true	"id of the job"
+// @Success 200 {object} app.BulletinJobDTO
+// @Failure 400 {object} string
+// @Router /v1/defect/bulletin/jobs/{id} [get]
+func (ctl DefectController) GetBulletinJob(ctx *gin.Context) {
+	if v, err := ctl.service.GetBulletinJob(ctx.Param("id")); err != nil {
+		if errors.Is(err, app.ErrNotFound) {
+			controller.SendFailedResp(ctx, errorNotFound, err)
+		} else {
+			controller.SendFailedResp(ctx, "", err)
+		}
+	} else {
+		controller.SendRespOfGet(ctx, v)
+	}
+}
+
+// UpdateBulletin
+// @Summary update security bulletin
+// @Description re-issue the security bulletin with a new revision under the same identification
+// @Tags  Defect
+// @Accept json
+// @Param	id     path  string	                 true	"identification of the bulletin"
+// @Param	param  body	 updateBulletinRequest	 true	"body of the revision"
+// @Success 202 {object} string
+// @Failure 400 {object} string
+// @Router /v1/defect/bulletin/{id} [put]
+func (ctl DefectController) UpdateBulletin(ctx *gin.Context) {
+	var req updateBulletinRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		controller.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	if err := ctl.service.UpdateBulletin(req.toCmd(ctx.Param("id"))); err != nil {
+		if errors.Is(err, app.ErrNotFound) {
+			controller.SendFailedResp(ctx, errorNotFound, err)
+		} else {
+			controller.SendFailedResp(ctx, "", err)
+		}
+	} else {
+		controller.SendRespOfPut(ctx)
+	}
+}
+
+// WithdrawBulletin
+// @Summary withdraw security bulletin
+// @Description withdraw the security bulletin published in error, the defects covered by it can be collected again
+// @Tags  Defect
+// @Accept json
+// @Param	id     path  string	                   true	"identification of the bulletin"
+// @Param	param  body	 withdrawBulletinRequest	 true	"body of the reason"
+// @Success 202 {object} string
+// @Failure 400 {object} string
+// @Router /v1/defect/bulletin/{id}/withdrawal [post]
+func (ctl DefectController) WithdrawBulletin(ctx *gin.Context) {
+	var req withdrawBulletinRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		controller.SendBadRequestBody(ctx, err)
+
+		return
+	}
+
+	err := ctl.service.WithdrawBulletin(req.toCmd(ctx.Param("id")))
+	switch {
+	case err == nil:
+		controller.SendRespOfPut(ctx)
+	case errors.Is(err, app.ErrNotFound):
+		controller.SendFailedResp(ctx, errorNotFound, err)
+	case errors.Is(err, app.ErrBulletinWithdrawn):
+		controller.SendFailedResp(ctx, errorBulletinWithdrawn, err)
+	default:
+		controller.SendFailedResp(ctx, "", err)
+	}
+}
+
+// GetBulletinDocument
+// @Summary get document of security bulletin
+// @Description get the security bulletin in the format, such as cvrf, csaf, markdown and html
+// @Tags  Defect
+// @Accept json
+// @Param	id      path  string	 true	"identification of the bulletin"
+// @Param	format  query string	 false	"format of the document, cvrf by default"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Router /v1/defect/bulletin/{id}/document [get]
+func (ctl DefectController) GetBulletinDocument(ctx *gin.Context) {
+	cmd := app.CmdToGetBulletinDocument{
+		Identification: ctx.Param("id"),
+		Format:         ctx.DefaultQuery("format", bulletin.FormatCVRF),
+	}
+
+	v, err := ctl.service.GetBulletinDocument(cmd)
+	switch {
+	case err == nil:
+		ctx.Data(http.StatusOK, v.ContentType, v.Data)
+	case errors.Is(err, app.ErrNotFound):
+		controller.SendFailedResp(ctx, errorNotFound, err)
+	case errors.Is(err, app.ErrUnsupportedFormat):
+		controller.SendBadRequestParam(ctx, err)
+	default:
+		controller.SendFailedResp(ctx, "", err)
+	}
+}
+
+// GetSigningKey
+// @Summary get the signing key of security bulletins
+// @Description get the fingerprint of the OpenPGP key which verifies the detached signatures of bulletins
+// @Tags  Defect
+// @Accept json
+// @Success 200 {object} app.SigningKeyDTO
+// @Failure 400 {object} string
+// @Router /v1/defect/bulletin/signing-key [get]
+func (ctl DefectController) GetSigningKey(ctx *gin.Context) {
+	if v, err := ctl.service.GetSigningKey(); err != nil {
+		if errors.Is(err, app.ErrSigningDisabled) {
+			controller.SendFailedResp(ctx, errorNotFound, err)
+		} else {
+			controller.SendFailedResp(ctx, "", err)
+		}
+	} else {
+		controller.SendRespOfGet(ctx, v)
+	}
+}
+
+// ListBulletin
+// @Summary list security bulletins which cover the defect
+// @Description list security bulletins which cover the defect
+// @Tags  Defect
+// @Accept json
+// @Param	number  query string	 true	"issue number of the defect"
+// @Param	org     query string	 false	"org of the issue"
+// @Success 200 {object} []app.BulletinDTO
+// @Failure 400 {object} string
+// @Router /v1/defect/bulletin [get]
+func (ctl DefectController) ListBulletin(ctx *gin.Context) {
+	cmd := app.CmdToFindBulletins{
+		Number: ctx.Query("number"),
+		Org:    ctx.Query("org"),
+	}
+	if cmd.Number == "" {
+		controller.SendBadRequestParam(ctx, errors.New("missing number"))
+
+		return
+	}
+
+	if v, err := ctl.service.FindBulletins(cmd); err != nil {
+		controller.SendFailedResp(ctx, "", err)
+	} else {
+		controller.SendRespOfGet(ctx, v)
+	}
+}
+
+// ExportOSV
+// @Summary export accepted defects as OSV records
+// @Description export the defects accepted after the date as OSV records
+// @Tags  Defect
+// @Accept json
+// @Param	date  query string	 true	"export defects after the date"
+// @Success 200 {object} []object
+// @Failure 400 {object} string
+// @Router /v1/defect/osv [get]
+func (ctl DefectController) ExportOSV(ctx *gin.Context) {
+	date, err := time.Parse("2006-01-02", ctx.Query("date"))
+	if err != nil {
+		controller.SendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if v, err := ctl.service.ExportOSV(date); err != nil {
+		controller.SendFailedResp(ctx, "", err)
+	} else {
+		controller.SendRespOfGet(ctx, v)
+	}
 }
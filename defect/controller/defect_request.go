@@ -1,5 +1,50 @@
 package controller
 
+import (
+	"github.com/opensourceways/defect-manager/defect/app"
+	"github.com/opensourceways/defect-manager/defect/domain"
+)
+
 type bulletinRequest struct {
 	IssueNumber []string `json:"issue_number" binding:"required"`
+	DryRun      bool     `json:"dry_run"`
+	// Grouping is the policy of grouping defects into bulletins: auto, component, component_version or manual
+	Grouping string `json:"grouping"`
+	// Groups are the issue numbers of each bulletin, which are required by the manual grouping
+	Groups [][]string `json:"groups"`
+}
+
+func (req bulletinRequest) toCmd() (cmd app.CmdToGenerateBulletins, err error) {
+	policy, err := domain.NewGroupingPolicy(req.Grouping, req.Groups)
+	if err != nil {
+		return
+	}
+
+	return app.CmdToGenerateBulletins{
+		IssueNumber: req.IssueNumber,
+		DryRun:      req.DryRun,
+		Policy:      policy,
+	}, nil
+}
+
+type updateBulletinRequest struct {
+	Description string `json:"description" binding:"required"`
+}
+
+func (req updateBulletinRequest) toCmd(identification string) app.CmdToUpdateBulletin {
+	return app.CmdToUpdateBulletin{
+		Identification: identification,
+		Description:    req.Description,
+	}
+}
+
+type withdrawBulletinRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+func (req withdrawBulletinRequest) toCmd(identification string) app.CmdToWithdrawBulletin {
+	return app.CmdToWithdrawBulletin{
+		Identification: identification,
+		Reason:         req.Reason,
+	}
 }
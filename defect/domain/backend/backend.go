@@ -1,6 +1,8 @@
 package backend
 
 type CveBackend interface {
-	MaxBulletinID() (int, error)
+	// MaxBulletinIdentification returns the identification of the latest bulletin published,
+	// it is empty if there is none
+	MaxBulletinIdentification() (string, error)
 	PublishedDefects() ([]string, error)
 }
@@ -2,6 +2,47 @@ package bulletin
 
 import "github.com/opensourceways/defect-manager/defect/domain"
 
+const (
+	FormatCVRF     = "cvrf"
+	FormatCSAF     = "csaf"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
 type Bulletin interface {
 	Generate(*domain.SecurityBulletin) ([]byte, error)
 }
+
+// Identifier formats and parses the identifications of bulletins, which are numbered by year
+type Identifier interface {
+	Identification(year, num int) string
+	// Preview is the placeholder identification of the bulletin which is not published
+	Preview(year, index int) string
+	Parse(identification string) (year, num int, err error)
+	// StartNumber is the number before the first one of each year
+	StartNumber() int
+}
+
+// Checker checks the structure of the generated document and the invariants of bulletin
+// before it is uploaded, the document which fails the check must not be published.
+type Checker interface {
+	Check([]byte) error
+	// CheckPreview checks the document which has the placeholder identification of preview
+	CheckPreview([]byte) error
+}
+
+// Signer signs the documents of bulletin, so that the consumers can verify where they come from
+type Signer interface {
+	// Sign returns the armored detached signature of the data
+	Sign([]byte) ([]byte, error)
+	// Fingerprint is the fingerprint of the public key which verifies the signatures
+	Fingerprint() string
+}
+
+// Format is a kind of document of bulletin, which is uploaded as a file with the extension
+type Format struct {
+	Name        string
+	Extension   string
+	ContentType string
+	Bulletin    Bulletin
+}
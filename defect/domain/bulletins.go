@@ -1,18 +1,79 @@
 package domain
 
 import (
+	"errors"
+
 	"github.com/opensourceways/defect-manager/defect/domain/dp"
 )
 
+const (
+	// GroupingCombined means all the defects of the component are put in one bulletin
+	GroupingCombined = "combined"
+	// GroupingSeparated means the defects of the component are split into bulletins by version
+	GroupingSeparated = "separated"
+	// GroupingManual means the defects are put into the bulletin by the user
+	GroupingManual = "manual"
+
+	initialRevision = "Initial"
+)
+
+// ErrBulletinWithdrawn is returned when withdrawing a bulletin which has been withdrawn
+var ErrBulletinWithdrawn = errors.New("bulletin has been withdrawn")
+
 type SecurityBulletin struct {
 	AffectedVersion []dp.SystemVersion
 	Identification  string
 	Date            string
 	Component       string
+	Grouping        string
+	Revisions       []Revision
+	Withdrawn       bool
 	ProductTree     ProductTree
 	Defects         Defects
 }
 
+// Revision is a release of the bulletin, the number starts from 1
+type Revision struct {
+	Number      int
+	Date        string
+	Description string
+}
+
+// InitialRevisions is the revision history of a bulletin released for the first time
+func InitialRevisions(date string) []Revision {
+	return []Revision{{
+		Number:      1,
+		Date:        date,
+		Description: initialRevision,
+	}}
+}
+
+// Version is the number of the latest revision
+func (sb *SecurityBulletin) Version() int {
+	return sb.Revisions[len(sb.Revisions)-1].Number
+}
+
+// InitialDate is the date when the bulletin was released for the first time
+func (sb *SecurityBulletin) InitialDate() string {
+	return sb.Revisions[0].Date
+}
+
+// AddRevision re-issues the bulletin at the date, the initial release date is kept
+func (sb *SecurityBulletin) AddRevision(description, date string) error {
+	if description == "" {
+		return errors.New("missing description of revision")
+	}
+
+	sb.Revisions = append(sb.Revisions, Revision{
+		Number:      sb.Version() + 1,
+		Date:        date,
+		Description: description,
+	})
+	sb.Date = date
+
+	return nil
+}
+
 type ProductTree = map[dp.Arch][]Product
 
 type Product struct {
@@ -20,3 +81,29 @@ type Product struct {
 	CPE      string
 	FullName string
 }
+
+// BulletinRecord is a generated bulletin with its xml document and the state of uploading it
+type BulletinRecord struct {
+	Bulletin     SecurityBulletin
+	Xml          []byte
+	UploadStatus dp.UploadStatus
+}
+
+// Withdraw marks the bulletin published in error as withdrawn with a revision giving the reason
+func (sb *SecurityBulletin) Withdraw(reason, date string) error {
+	if sb.Withdrawn {
+		return ErrBulletinWithdrawn
+	}
+
+	if reason == "" {
+		return errors.New("missing reason of withdrawal")
+	}
+
+	if err := sb.AddRevision("Withdrawn: "+reason, date); err != nil {
+		return err
+	}
+
+	sb.Withdrawn = true
+
+	return nil
+}
@@ -1,9 +1,10 @@
 package domain
 
 import (
-	"github.com/opensourceways/defect-manager/defect/domain/dp"
+	"sort"
+	"time"
 
-	"github.com/opensourceways/defect-manager/utils"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
 )
 
 type Defects []Defect
@@ -14,19 +15,31 @@ type DefectsByComponent []Defect
 // DefectsByVersion is group of DefectsByComponent by version
 type DefectsByVersion []Defect
 
+// Defect is described in Chinese usually, DescriptionEn and InfluenceEn are the optional English translations
 type Defect struct {
 	Kernel           string
 	Component        string
 	ComponentVersion string
 	SystemVersion    dp.SystemVersion
 	Description      string
+	DescriptionEn    string
 	ReferenceURL     dp.URL
 	GuidanceURL      dp.URL
 	Influence        string
+	InfluenceEn      string
 	SeverityLevel    dp.SeverityLevel
 	AffectedVersion  []dp.SystemVersion
 	ABI              string
+	MergedPR         []PullRequest
 	Issue            Issue
+	// AcceptedAt is the unix time when the defect was accepted
+	AcceptedAt int64
+}
+
+// PullRequest is the merged pull request which fixes the defect on the branch of the version
+type PullRequest struct {
+	Version dp.SystemVersion
+	URL     dp.URL
 }
 
 type Issue struct {
@@ -37,6 +50,12 @@ type Issue struct {
 	Status dp.IssueStatus
 }
 
+// AcceptedDate is the date in UTC when the defect was accepted, so that it does not depend on the
+// time zone of the server
+func (d Defect) AcceptedDate() string {
+	return time.Unix(d.AcceptedAt, 0).UTC().Format("2006-01-02")
+}
+
 func (d Defect) isAffectVersion(version dp.SystemVersion) bool {
 	for _, v := range d.AffectedVersion {
 		if v == version {
@@ -47,31 +66,26 @@ func (d Defect) isAffectVersion(version dp.SystemVersion) bool {
 	return false
 }
 
-// GroupByComponent group defects by component
-func (ds Defects) groupByComponent() map[string]DefectsByComponent {
+// GroupByComponent group defects by component, the components are sorted by name
+func (ds Defects) groupByComponent() ([]string, map[string]DefectsByComponent) {
+	var components []string
 	group := make(map[string]DefectsByComponent)
 	for _, d := range ds {
+		if _, ok := group[d.Component]; !ok {
+			components = append(components, d.Component)
+		}
+
 		group[d.Component] = append(group[d.Component], d)
 	}
 
-	return group
-}
+	sort.Strings(components)
 
-// GenerateBulletins DefectsByComponent is a component-differentiated set of defects,
-// Bulletins are consolidated into one when all issues of a component affect all versions currently maintained,
-// otherwise they are split into multiple bulletins by version
-func (ds Defects) GenerateBulletins() []SecurityBulletin {
-	var securityBulletins []SecurityBulletin
-
-	for _, dsc := range ds.groupByComponent() {
-		if dsc.isCombined() {
-			securityBulletins = append(securityBulletins, dsc.combinedBulletin())
-		} else {
-			securityBulletins = append(securityBulletins, dsc.separatedBulletins()...)
-		}
-	}
+	return components, group
+}
 
-	return securityBulletins
+// GenerateBulletins groups the defects into bulletins by the policy
+func (ds Defects) GenerateBulletins(policy GroupingPolicy, date string) ([]SecurityBulletin, error) {
+	return policy.Group(ds, date)
 }
 
 // IsCombined determine whether multiple defects under the same component
@@ -93,43 +107,92 @@ func (dsc DefectsByComponent) isCombined() bool {
 }
 
 // CombinedBulletin put all defects in one bulletin
-func (dsc DefectsByComponent) combinedBulletin() SecurityBulletin {
+func (dsc DefectsByComponent) combinedBulletin(versions []dp.SystemVersion, grouping, date string) SecurityBulletin {
 	return SecurityBulletin{
-		AffectedVersion: dsc[0].AffectedVersion,
-		Date:            utils.Date(),
+		AffectedVersion: versions,
+		Date:            date,
 		Component:       dsc[0].Component,
+		Grouping:        grouping,
+		Revisions:       InitialRevisions(date),
 		Defects:         Defects(dsc),
 	}
 }
 
-// SeparatedBulletins split into multiple bulletins by version
-func (dsc DefectsByComponent) separatedBulletins() []SecurityBulletin {
-	var sbs []SecurityBulletin
-	for version, ds := range dsc.separateByVersion() {
-		sbs = append(sbs, ds.bulletinByVersion(version))
+// affectedVersions is the union of versions affected by the defects, which is sorted
+func (dsc DefectsByComponent) affectedVersions() []dp.SystemVersion {
+	var versions []dp.SystemVersion
+	has := make(map[string]bool)
+	for _, d := range dsc {
+		for _, v := range d.AffectedVersion {
+			if !has[v.String()] {
+				has[v.String()] = true
+				versions = append(versions, v)
+			}
+		}
+	}
+
+	sortVersions(versions)
+
+	return versions
+}
+
+// SeparatedBulletins split into multiple bulletins by the versions
+func (dsc DefectsByComponent) separatedBulletins(versions []dp.SystemVersion, date string) []SecurityBulletin {
+	versions, classifyByVersion := dsc.separateByVersion(versions)
+
+	sbs := make([]SecurityBulletin, len(versions))
+	for k, version := range versions {
+		sbs[k] = classifyByVersion[version].bulletinByVersion(version, date)
 	}
 
 	return sbs
 }
 
-func (dsc DefectsByComponent) separateByVersion() map[dp.SystemVersion]DefectsByVersion {
+// separateByVersion classifies the defects by the versions they affect, the versions affected by none are dropped
+func (dsc DefectsByComponent) separateByVersion(candidates []dp.SystemVersion) (
+	[]dp.SystemVersion, map[dp.SystemVersion]DefectsByVersion,
+) {
+	var versions []dp.SystemVersion
 	classifyByVersion := make(map[dp.SystemVersion]DefectsByVersion)
-	for version := range dp.MaintainVersion {
+	for _, version := range candidates {
 		for _, d := range dsc {
 			if d.isAffectVersion(version) {
 				classifyByVersion[version] = append(classifyByVersion[version], d)
 			}
 		}
+
+		if len(classifyByVersion[version]) > 0 {
+			versions = append(versions, version)
+		}
 	}
 
-	return classifyByVersion
+	return versions, classifyByVersion
+}
+
+func sortedMaintainVersion() []dp.SystemVersion {
+	versions := make([]dp.SystemVersion, 0, len(dp.MaintainVersion))
+	for version := range dp.MaintainVersion {
+		versions = append(versions, version)
+	}
+
+	sortVersions(versions)
+
+	return versions
+}
+
+func sortVersions(versions []dp.SystemVersion) {
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].String() < versions[j].String()
+	})
 }
 
-func (dsv DefectsByVersion) bulletinByVersion(version dp.SystemVersion) SecurityBulletin {
+func (dsv DefectsByVersion) bulletinByVersion(version dp.SystemVersion, date string) SecurityBulletin {
 	return SecurityBulletin{
 		AffectedVersion: []dp.SystemVersion{version},
-		Date:            utils.Date(),
+		Date:            date,
 		Component:       dsv[0].Component,
+		Grouping:        GroupingSeparated,
+		Revisions:       InitialRevisions(date),
 		Defects:         Defects(dsv),
 	}
 }
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAcceptedDate(t *testing.T) {
+	local := time.Local
+	defer func() { time.Local = local }()
+
+	tests := []struct {
+		name       string
+		acceptedAt time.Time
+		want       string
+	}{
+		{"last second of year", time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC), "2023-12-31"},
+		{"first second of year", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "2024-01-01"},
+	}
+
+	// the date is the same in the time zones before and after UTC
+	for _, zone := range []*time.Location{time.FixedZone("UTC+8", 8*3600), time.FixedZone("UTC-5", -5*3600)} {
+		time.Local = zone
+
+		for _, tt := range tests {
+			d := Defect{AcceptedAt: tt.acceptedAt.Unix()}
+			if got := d.AcceptedDate(); got != tt.want {
+				t.Errorf("%s in %s: want %s, got %s", tt.name, zone, tt.want, got)
+			}
+		}
+	}
+}
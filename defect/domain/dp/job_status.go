@@ -0,0 +1,39 @@
+package dp
+
+import "errors"
+
+const (
+	jobRunning   = "running"
+	jobSucceeded = "succeeded"
+	jobFailed    = "failed"
+)
+
+var (
+	validJobStatus = map[string]bool{
+		jobRunning:   true,
+		jobSucceeded: true,
+		jobFailed:    true,
+	}
+
+	JobStatusRunning   = jobStatus(jobRunning)
+	JobStatusSucceeded = jobStatus(jobSucceeded)
+	JobStatusFailed    = jobStatus(jobFailed)
+)
+
+type jobStatus string
+
+type JobStatus interface {
+	String() string
+}
+
+func NewJobStatus(s string) (JobStatus, error) {
+	if !validJobStatus[s] {
+		return nil, errors.New("invalid job status")
+	}
+
+	return jobStatus(s), nil
+}
+
+func (s jobStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,39 @@
+package dp
+
+import "errors"
+
+const (
+	uploadPending = "pending"
+	uploadSucceed = "uploaded"
+	uploadFailed  = "failed"
+)
+
+var (
+	validUploadStatus = map[string]bool{
+		uploadPending: true,
+		uploadSucceed: true,
+		uploadFailed:  true,
+	}
+
+	UploadStatusPending = uploadStatus(uploadPending)
+	UploadStatusSucceed = uploadStatus(uploadSucceed)
+	UploadStatusFailed  = uploadStatus(uploadFailed)
+)
+
+type uploadStatus string
+
+type UploadStatus interface {
+	String() string
+}
+
+func NewUploadStatus(s string) (UploadStatus, error) {
+	if !validUploadStatus[s] {
+		return nil, errors.New("invalid upload status")
+	}
+
+	return uploadStatus(s), nil
+}
+
+func (s uploadStatus) String() string {
+	return string(s)
+}
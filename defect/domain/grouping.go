@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// GroupingPolicyAuto combines the defects of a component when all of them affect all the maintained versions,
+	// otherwise splits them by version
+	GroupingPolicyAuto = "auto"
+	// GroupingPolicyComponent always puts the defects of a component in one bulletin
+	GroupingPolicyComponent = "component"
+	// GroupingPolicyComponentVersion always splits the defects of a component into bulletins by version
+	GroupingPolicyComponentVersion = "component_version"
+	// GroupingPolicyManual puts each group of defects given by the user in one bulletin
+	GroupingPolicyManual = "manual"
+)
+
+// GroupingPolicy decides which defects are published in the same bulletin.
+// The same defects generate the same bulletins in the same order.
+type GroupingPolicy interface {
+	Group(ds Defects, date string) ([]SecurityBulletin, error)
+}
+
+// NewGroupingPolicy returns the policy of name, the groups of issue number are required by the manual one only
+func NewGroupingPolicy(name string, groups [][]string) (GroupingPolicy, error) {
+	if name != GroupingPolicyManual && len(groups) > 0 {
+		return nil, errors.New("groups are only allowed by the manual grouping policy")
+	}
+
+	switch name {
+	case "", GroupingPolicyAuto:
+		return autoGrouping{}, nil
+
+	case GroupingPolicyComponent:
+		return componentGrouping{}, nil
+
+	case GroupingPolicyComponentVersion:
+		return componentVersionGrouping{}, nil
+
+	case GroupingPolicyManual:
+		if len(groups) == 0 {
+			return nil, errors.New("missing groups of the manual grouping policy")
+		}
+
+		return manualGrouping{groups: groups}, nil
+	}
+
+	return nil, fmt.Errorf("invalid grouping policy: %s", name)
+}
+
+type autoGrouping struct{}
+
+func (g autoGrouping) Group(ds Defects, date string) ([]SecurityBulletin, error) {
+	var sbs []SecurityBulletin
+
+	components, group := ds.groupByComponent()
+	for _, component := range components {
+		dsc := group[component]
+		if dsc.isCombined() {
+			sbs = append(sbs, dsc.combinedBulletin(sortedMaintainVersion(), GroupingCombined, date))
+		} else {
+			sbs = append(sbs, dsc.separatedBulletins(sortedMaintainVersion(), date)...)
+		}
+	}
+
+	return sbs, nil
+}
+
+type componentGrouping struct{}
+
+func (g componentGrouping) Group(ds Defects, date string) ([]SecurityBulletin, error) {
+	components, group := ds.groupByComponent()
+
+	sbs := make([]SecurityBulletin, len(components))
+	for k, component := range components {
+		dsc := group[component]
+		sbs[k] = dsc.combinedBulletin(dsc.affectedVersions(), GroupingCombined, date)
+	}
+
+	return sbs, nil
+}
+
+type componentVersionGrouping struct{}
+
+func (g componentVersionGrouping) Group(ds Defects, date string) ([]SecurityBulletin, error) {
+	var sbs []SecurityBulletin
+
+	components, group := ds.groupByComponent()
+	for _, component := range components {
+		dsc := group[component]
+		sbs = append(sbs, dsc.separatedBulletins(dsc.affectedVersions(), date)...)
+	}
+
+	return sbs, nil
+}
+
+// manualGrouping requires that every defect is in exactly one group,
+// and the defects of a group belong to the same component
+type manualGrouping struct {
+	groups [][]string
+}
+
+func (g manualGrouping) Group(ds Defects, date string) ([]SecurityBulletin, error) {
+	defects := make(map[string]Defect)
+	for _, d := range ds {
+		defects[d.Issue.Number] = d
+	}
+
+	grouped := make(map[string]bool)
+	sbs := make([]SecurityBulletin, 0, len(g.groups))
+
+	for _, numbers := range g.groups {
+		if len(numbers) == 0 {
+			return nil, errors.New("empty group")
+		}
+
+		var dsc DefectsByComponent
+		for _, n := range numbers {
+			d, ok := defects[n]
+			if !ok {
+				return nil, fmt.Errorf("defect %s of group is not found", n)
+			}
+
+			if grouped[n] {
+				return nil, fmt.Errorf("defect %s is in more than one group", n)
+			}
+			grouped[n] = true
+
+			if len(dsc) > 0 && dsc[0].Component != d.Component {
+				return nil, fmt.Errorf("defects of different components are in the same group: %s, %s",
+					dsc[0].Issue.Number, n,
+				)
+			}
+
+			dsc = append(dsc, d)
+		}
+
+		sbs = append(sbs, dsc.combinedBulletin(dsc.affectedVersions(), GroupingManual, date))
+	}
+
+	for _, d := range ds {
+		if !grouped[d.Issue.Number] {
+			return nil, fmt.Errorf("defect %s is not in any group", d.Issue.Number)
+		}
+	}
+
+	return sbs, nil
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+const (
+	version2003 = "openEuler-20.03-LTS-SP4"
+	version2203 = "openEuler-22.03-LTS-SP3"
+)
+
+func testDefect(number, component string, versions ...string) Defect {
+	d := Defect{
+		Component: component,
+		Issue:     Issue{Number: number},
+	}
+
+	for _, v := range versions {
+		version, _ := dp.NewSystemVersion(v)
+		d.AffectedVersion = append(d.AffectedVersion, version)
+	}
+
+	return d
+}
+
+// summary describes each bulletin as "<component> <grouping> <versions> <issue numbers>"
+func summary(sbs []SecurityBulletin) []string {
+	s := make([]string, len(sbs))
+	for k, sb := range sbs {
+		versions := make([]string, len(sb.AffectedVersion))
+		for i, v := range sb.AffectedVersion {
+			versions[i] = v.String()
+		}
+
+		numbers := make([]string, len(sb.Defects))
+		for i, d := range sb.Defects {
+			numbers[i] = d.Issue.Number
+		}
+
+		s[k] = fmt.Sprintf("%s %s %s %s",
+			sb.Component, sb.Grouping, strings.Join(versions, ","), strings.Join(numbers, ","),
+		)
+	}
+
+	return s
+}
+
+func testDefects() Defects {
+	return Defects{
+		testDefect("I3", "zbar", version2203),
+		testDefect("I1", "kernel", version2003, version2203),
+		testDefect("I4", "zbar", version2003, version2203),
+		testDefect("I2", "kernel", version2203, version2003),
+	}
+}
+
+func TestGroupingPolicies(t *testing.T) {
+	dp.Init([]string{version2003, version2203})
+
+	cases := []struct {
+		name   string
+		policy string
+		groups [][]string
+		ds     Defects
+		want   []string
+	}{
+		// the defects of a component affecting all the maintained versions are combined,
+		// the others are split by the maintained versions
+		{
+			name:   "auto",
+			policy: GroupingPolicyAuto,
+			ds:     testDefects(),
+			want: []string{
+				"kernel combined openEuler-20.03-LTS-SP4,openEuler-22.03-LTS-SP3 I1,I2",
+				"zbar separated openEuler-20.03-LTS-SP4 I4",
+				"zbar separated openEuler-22.03-LTS-SP3 I3,I4",
+			},
+		},
+		{
+			name:   "auto by default",
+			policy: "",
+			ds:     Defects{testDefect("I1", "kernel", version2003, version2203)},
+			want:   []string{"kernel combined openEuler-20.03-LTS-SP4,openEuler-22.03-LTS-SP3 I1"},
+		},
+		{
+			name:   "auto of the version not maintained",
+			policy: GroupingPolicyAuto,
+			ds:     Defects{testDefect("I1", "kernel", version2003, "openEuler-24.03-LTS")},
+			want:   []string{"kernel separated openEuler-20.03-LTS-SP4 I1"},
+		},
+		{
+			name:   "component",
+			policy: GroupingPolicyComponent,
+			ds:     testDefects(),
+			want: []string{
+				"kernel combined openEuler-20.03-LTS-SP4,openEuler-22.03-LTS-SP3 I1,I2",
+				"zbar combined openEuler-20.03-LTS-SP4,openEuler-22.03-LTS-SP3 I3,I4",
+			},
+		},
+		{
+			name:   "component version",
+			policy: GroupingPolicyComponentVersion,
+			ds:     testDefects(),
+			want: []string{
+				"kernel separated openEuler-20.03-LTS-SP4 I1,I2",
+				"kernel separated openEuler-22.03-LTS-SP3 I1,I2",
+				"zbar separated openEuler-20.03-LTS-SP4 I4",
+				"zbar separated openEuler-22.03-LTS-SP3 I3,I4",
+			},
+		},
+		{
+			name:   "manual",
+			policy: GroupingPolicyManual,
+			groups: [][]string{{"I4"}, {"I2", "I1"}, {"I3"}},
+			ds:     testDefects(),
+			want: []string{
+				"zbar manual openEuler-20.03-LTS-SP4,openEuler-22.03-LTS-SP3 I4",
+				"kernel manual openEuler-20.03-LTS-SP4,openEuler-22.03-LTS-SP3 I2,I1",
+				"zbar manual openEuler-22.03-LTS-SP3 I3",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			policy, err := NewGroupingPolicy(c.policy, c.groups)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sbs, err := c.ds.GenerateBulletins(policy, "2024-03-01")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := summary(sbs); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("want:\n%s\ngot:\n%s", strings.Join(c.want, "\n"), strings.Join(got, "\n"))
+			}
+
+			for _, sb := range sbs {
+				if sb.Date != "2024-03-01" || len(sb.Revisions) == 0 {
+					t.Errorf("the bulletin of %s is not dated: %v", sb.Component, sb)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupingPolicyErrors(t *testing.T) {
+	dp.Init([]string{version2003, version2203})
+
+	cases := []struct {
+		name   string
+		policy string
+		groups [][]string
+		want   string
+	}{
+		{"unknown policy", "vendor", nil, "invalid grouping policy"},
+		{"groups of auto", GroupingPolicyAuto, [][]string{{"I1"}}, "only allowed by the manual"},
+		{"manual without groups", GroupingPolicyManual, nil, "missing groups"},
+		{"empty group", GroupingPolicyManual, [][]string{{"I1", "I2"}, {}, {"I3", "I4"}}, "empty group"},
+		{"unknown defect", GroupingPolicyManual, [][]string{{"I1", "I2", "I5"}, {"I3", "I4"}}, "I5 of group is not found"},
+		{
+			"defect listed twice", GroupingPolicyManual,
+			[][]string{{"I1", "I2"}, {"I3", "I4"}, {"I1"}}, "I1 is in more than one group",
+		},
+		{"defect left out", GroupingPolicyManual, [][]string{{"I1", "I2"}, {"I3"}}, "I4 is not in any group"},
+		{"different components", GroupingPolicyManual, [][]string{{"I1", "I3"}, {"I2", "I4"}}, "different components"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			policy, err := NewGroupingPolicy(c.policy, c.groups)
+			if err == nil {
+				_, err = testDefects().GenerateBulletins(policy, "2024-03-01")
+			}
+
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Errorf("want error containing %q, got %v", c.want, err)
+			}
+		})
+	}
+}
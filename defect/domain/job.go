@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+// BulletinJob is a task of generating bulletins for some defects
+type BulletinJob struct {
+	Id          string
+	IssueNumber []string
+	Status      dp.JobStatus
+	Error       string
+	Items       []BulletinJobItem
+	CreatedAt   int64
+	UpdatedAt   int64
+}
+
+// BulletinJobItem is the result of generating one bulletin in the job
+type BulletinJobItem struct {
+	Identification  string
+	Component       string
+	AffectedVersion []dp.SystemVersion
+	IssueNumber     []string
+	Status          dp.JobStatus
+	Error           string
+}
+
+func NewBulletinJobItem(sb *SecurityBulletin) BulletinJobItem {
+	var numbers []string
+	for _, d := range sb.Defects {
+		numbers = append(numbers, d.Issue.Number)
+	}
+
+	return BulletinJobItem{
+		Identification:  sb.Identification,
+		Component:       sb.Component,
+		AffectedVersion: sb.AffectedVersion,
+		IssueNumber:     numbers,
+		Status:          dp.JobStatusRunning,
+	}
+}
+
+func (item *BulletinJobItem) Fail(err error) {
+	item.Status = dp.JobStatusFailed
+	item.Error = err.Error()
+}
+
+func (item *BulletinJobItem) Succeed() {
+	item.Status = dp.JobStatusSucceeded
+}
+
+// Finish sets the status of job by the result of all the items
+func (job *BulletinJob) Finish(err error) {
+	job.Status = dp.JobStatusSucceeded
+	if err != nil {
+		job.Status = dp.JobStatusFailed
+		job.Error = err.Error()
+	}
+
+	for _, item := range job.Items {
+		if item.Status != dp.JobStatusSucceeded {
+			job.Status = dp.JobStatusFailed
+		}
+	}
+}
+
+// Interrupt fails the job which was stopped before it finished, such as by the restart
+// of the service, the items not finished are failed with the reason too.
+func (job *BulletinJob) Interrupt(reason string) {
+	job.Status = dp.JobStatusFailed
+	job.Error = reason
+
+	for k := range job.Items {
+		if job.Items[k].Status == dp.JobStatusRunning {
+			job.Items[k].Fail(errors.New(reason))
+		}
+	}
+}
@@ -1,5 +1,29 @@
 package obs
 
+import "errors"
+
+// ErrNotFound is returned when the file to download does not exist
+var ErrNotFound = errors.New("file not found")
+
+// OBS stores the files under its directory, the path of file is relative to the directory
 type OBS interface {
-	Upload(fileName string, data []byte) error
+	// Upload replaces the file of the path if it exists
+	Upload(path string, data []byte) error
+	Download(path string) ([]byte, error)
+	// List returns the paths of all the files
+	List() ([]string, error)
+}
+
+// Committer is implemented by the storage which publishes the files uploaded together,
+// such as a git repository. The files are not published until they are committed.
+type Committer interface {
+	// Commit publishes the files uploaded, the paths are of the bulletins uploaded
+	Commit(paths []string) error
+}
+
+// Layout is implemented by the storage which stores the files in a layout of its own,
+// such as a git repository which puts the files of a date under the directory of the year.
+type Layout interface {
+	// StoragePath returns the path which the file of the path is stored at
+	StoragePath(path string) string
 }
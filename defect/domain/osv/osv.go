@@ -0,0 +1,8 @@
+package osv
+
+import "github.com/opensourceways/defect-manager/defect/domain"
+
+type OSV interface {
+	Identification(*domain.Defect) string
+	Generate(*domain.Defect) ([]byte, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/opensourceways/defect-manager/defect/domain"
+)
+
+type OptToFindBulletins struct {
+	Org    string
+	Number string
+}
+
+type BulletinRepository interface {
+	AddBulletin(*domain.BulletinRecord) error
+	SaveBulletin(*domain.BulletinRecord) error
+	FindBulletin(identification string) (domain.BulletinRecord, error)
+	FindBulletins(OptToFindBulletins) ([]domain.BulletinRecord, error)
+	FindWithdrawnDefects() ([]string, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+type BulletinJobRepository interface {
+	AddJob(*domain.BulletinJob) error
+	SaveJob(*domain.BulletinJob) error
+	FindJob(id string) (domain.BulletinJob, error)
+	FindJobs(status dp.JobStatus) ([]domain.BulletinJob, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+// BulletinSequence allocates the number part of the bulletin identification.
+// The numbers are scoped by year, and a released number will be allocated again
+// before a new one is taken, so that there is no gap in the published identifications.
+// The sequence of a year starts after the number given when it is used for the first time.
+type BulletinSequence interface {
+	Reconcile(year, maxNum int) error
+	Allocate(year, start int) (int, error)
+	Release(year, num int) error
+}
@@ -0,0 +1,6 @@
+package repository
+
+import "errors"
+
+// ErrNotFound is returned when the record to find does not exist
+var ErrNotFound = errors.New("not found")
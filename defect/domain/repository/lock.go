@@ -0,0 +1,12 @@
+package repository
+
+import "time"
+
+// Lock runs the function exclusively among all the instances of the service,
+// the functions of the same name wait for each other.
+type Lock interface {
+	// Do fails if the lock is not got in the timeout, and the lock is released
+	// if it is held longer than the timeout, so that an instance which hangs
+	// can not block the others.
+	Do(name string, timeout time.Duration, f func() error) error
+}
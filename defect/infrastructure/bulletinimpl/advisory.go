@@ -0,0 +1,180 @@
+package bulletinimpl
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+)
+
+const (
+	markdownTemplate = "templates/advisory.md.tmpl"
+	htmlTemplate     = "templates/advisory.html.tmpl"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var templateFuncs = map[string]interface{}{
+	"join": strings.Join,
+}
+
+// executor is implemented by both the text and html template
+type executor interface {
+	Execute(io.Writer, interface{}) error
+}
+
+// advisoryImpl renders the human-readable advisory of bulletin by the template
+type advisoryImpl struct {
+	bulletinImpl
+
+	tmpl executor
+}
+
+// readTemplate reads the template configured, or the default one if it is not configured
+func readTemplate(path, defaultPath string) (string, error) {
+	var (
+		b   []byte
+		err error
+	)
+
+	if path == "" {
+		b, err = templates.ReadFile(defaultPath)
+	} else {
+		b, err = os.ReadFile(path)
+	}
+
+	return string(b), err
+}
+
+func newMarkdownImpl(impl bulletinImpl) (*advisoryImpl, error) {
+	s, err := readTemplate(impl.cfg.MarkdownTemplate, markdownTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("markdown").Funcs(templateFuncs).Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("parse markdown template error: %s", err.Error())
+	}
+
+	return &advisoryImpl{bulletinImpl: impl, tmpl: tmpl}, nil
+}
+
+func newHtmlImpl(impl bulletinImpl) (*advisoryImpl, error) {
+	s, err := readTemplate(impl.cfg.HtmlTemplate, htmlTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := htmltemplate.New("html").Funcs(templateFuncs).Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("parse html template error: %s", err.Error())
+	}
+
+	return &advisoryImpl{bulletinImpl: impl, tmpl: tmpl}, nil
+}
+
+type advisory struct {
+	Identification  string
+	Title           string
+	Synopsis        string
+	Component       string
+	Severity        string
+	AffectedVersion []string
+	InitialDate     string
+	Date            string
+	Version         int
+	Withdrawn       bool
+	Url             string
+	Defects         []advisoryDefect
+	Packages        []advisoryPackages
+	References      []string
+}
+
+type advisoryDefect struct {
+	BugID           string
+	Title           string
+	Description     string
+	Severity        string
+	AffectedVersion []string
+	IssueUrl        string
+}
+
+type advisoryPackages struct {
+	Arch  string
+	Names []string
+}
+
+func (impl advisoryImpl) Generate(sb *domain.SecurityBulletin) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := impl.tmpl.Execute(&buf, impl.advisory(sb)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (impl advisoryImpl) advisory(sb *domain.SecurityBulletin) advisory {
+	var affectedVersion []string
+	for _, v := range sb.AffectedVersion {
+		affectedVersion = append(affectedVersion, v.String())
+	}
+
+	defects := make([]advisoryDefect, len(sb.Defects))
+	for k, d := range sb.Defects {
+		var dv []string
+		for _, v := range d.AffectedVersion {
+			dv = append(dv, v.String())
+		}
+
+		defects[k] = advisoryDefect{
+			BugID:           impl.bugID(&d),
+			Title:           d.Issue.Title,
+			Description:     strings.TrimSpace(d.Description),
+			Severity:        d.SeverityLevel.String(),
+			AffectedVersion: dv,
+			IssueUrl: fmt.Sprintf("https://gitee.com/%s/%s/issues/%s",
+				d.Issue.Org, d.Issue.Repo, d.Issue.Number,
+			),
+		}
+	}
+
+	var packages []advisoryPackages
+	for _, arch := range sortedArches(sb) {
+		var names []string
+		for _, p := range sb.ProductTree[arch] {
+			names = append(names, p.FullName)
+		}
+
+		packages = append(packages, advisoryPackages{
+			Arch:  arch.String(),
+			Names: names,
+		})
+	}
+
+	refs := newDefectReferences(sb)
+
+	return advisory{
+		Identification:  sb.Identification,
+		Title:           impl.documentTitle(sb).DocumentTitle,
+		Synopsis:        fmt.Sprintf("%s bug update", sb.Component),
+		Component:       sb.Component,
+		Severity:        impl.severity(sb),
+		AffectedVersion: affectedVersion,
+		InitialDate:     sb.InitialDate(),
+		Date:            sb.Date,
+		Version:         sb.Version(),
+		Withdrawn:       sb.Withdrawn,
+		Url:             impl.cfg.SecurityBulletinUrlPrefix + sb.Identification,
+		Defects:         defects,
+		Packages:        packages,
+		References:      append(append(refs.reference, refs.guidance...), refs.pullRequest...),
+	}
+}
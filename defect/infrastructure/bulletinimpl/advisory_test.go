@@ -0,0 +1,91 @@
+package bulletinimpl
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+func TestGenerateAdvisory(t *testing.T) {
+	impl := newTestBulletinImpl()
+
+	markdown, err := newMarkdownImpl(impl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	html, err := newHtmlImpl(impl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withPR := testDefect("I8ABCD", "High", version2003, version2203)
+	withPR.Description = "crash when scanning the image of <I8ABCD> & others"
+	withPR.MergedPR = []domain.PullRequest{{
+		Version: systemVersions(version2203)[0],
+		URL:     url("https://gitee.com/src-openeuler/zbar/pulls/12"),
+	}}
+
+	sb := domain.SecurityBulletin{
+		AffectedVersion: systemVersions(version2003, version2203),
+		Identification:  "cvrf-openEuler-BA-2024-1005",
+		Date:            date,
+		Component:       "zbar",
+		Grouping:        domain.GroupingCombined,
+		Revisions:       domain.InitialRevisions(date),
+		ProductTree: domain.ProductTree{
+			dp.NewArch("x86_64"): {
+				product(version2003, "zbar-0.22-4.oe2003sp4.x86_64.rpm", "zbar-0.22-4"),
+				product(version2203, "zbar-0.22-5.oe2203sp3.x86_64.rpm", "zbar-0.22-5"),
+			},
+			dp.NewArch("src"): {
+				product(version2003, "zbar-0.22-4.oe2003sp4.src.rpm", "zbar-0.22-4"),
+				product(version2203, "zbar-0.22-5.oe2203sp3.src.rpm", "zbar-0.22-5"),
+			},
+		},
+		Defects: domain.Defects{
+			testDefect("I7ZZZZ", "Moderate", version2003, version2203),
+			withPR,
+		},
+	}
+	if err = sb.Withdraw("published by mistake", "2024-03-20"); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		golden string
+		impl   *advisoryImpl
+	}{
+		{golden: "advisory.md", impl: markdown},
+		{golden: "advisory.html", impl: html},
+	}
+
+	for _, c := range cases {
+		t.Run(c.golden, func(t *testing.T) {
+			got, err := c.impl.Generate(&sb)
+			if err != nil {
+				t.Fatalf("generate error: %s", err.Error())
+			}
+
+			golden := filepath.Join("testdata", c.golden)
+			if *update {
+				if err = os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatalf("update golden file error: %s", err.Error())
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden file error: %s", err.Error())
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("the advisory differs from %s, run with -update if it is expected:\n%s", golden, got)
+			}
+		})
+	}
+}
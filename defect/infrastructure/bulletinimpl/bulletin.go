@@ -6,23 +6,96 @@ import (
 	"strconv"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/bulletin"
 	"github.com/opensourceways/defect-manager/defect/domain/dp"
-	"github.com/opensourceways/defect-manager/utils"
 )
 
-var instance *bulletinImpl
+var (
+	instance           *bulletinImpl
+	identifierInstance *identifier
+	formats            []bulletin.Format
+)
+
+func Init(cfg *Config) (err error) {
+	if identifierInstance, err = newIdentifier(cfg.IdentificationFormat, cfg.StartNumber); err != nil {
+		return
+	}
+
+	structureCheckerInstance = structureChecker{identifier: identifierInstance}
+
+	signerInstance = nil
+	if cfg.SigningKeyring != "" {
+		if signerInstance, err = newSigner(cfg.SigningKeyring, cfg.SigningKeyId, cfg.SigningPassphrase); err != nil {
+			return
+		}
+	}
 
-func Init(cfg *Config) {
 	instance = &bulletinImpl{
 		cfg: cfg,
 	}
+
+	csafInstance = &csafImpl{
+		bulletinImpl: *instance,
+	}
+
+	markdown, err := newMarkdownImpl(*instance)
+	if err != nil {
+		return err
+	}
+
+	html, err := newHtmlImpl(*instance)
+	if err != nil {
+		return err
+	}
+
+	all := []bulletin.Format{
+		{
+			Name:        bulletin.FormatCSAF,
+			Extension:   ".json",
+			ContentType: "application/json",
+			Bulletin:    csafInstance,
+		},
+		{
+			Name:        bulletin.FormatMarkdown,
+			Extension:   ".md",
+			ContentType: "text/markdown; charset=utf-8",
+			Bulletin:    markdown,
+		},
+		{
+			Name:        bulletin.FormatHTML,
+			Extension:   ".html",
+			ContentType: "text/html; charset=utf-8",
+			Bulletin:    html,
+		},
+	}
+
+	configured := sets.NewString(cfg.Formats...)
+	formats = nil
+	for _, f := range all {
+		if configured.Has(f.Name) {
+			formats = append(formats, f)
+		}
+	}
+
+	return nil
 }
 
 func Instance() *bulletinImpl {
 	return instance
 }
 
+func Identifier() *identifier {
+	return identifierInstance
+}
+
+// ExtraFormats are the formats configured to upload besides cvrf
+func ExtraFormats() []bulletin.Format {
+	return formats
+}
+
 type bulletinImpl struct {
 	cfg *Config
 }
@@ -53,9 +126,14 @@ func (impl bulletinImpl) joinVersion(sb *domain.SecurityBulletin) string {
 	return strings.Trim(title, ",")
 }
 
+// summary is the note which tells the versions the bulletin is for
+func (impl bulletinImpl) summary(sb *domain.SecurityBulletin) string {
+	return fmt.Sprintf(impl.cfg.SummaryFormat, impl.joinVersion(sb))
+}
+
 func (impl bulletinImpl) documentTitle(sb *domain.SecurityBulletin) DocumentTitle {
-	title := fmt.Sprintf("openEuler Bug Fix Advisory: %s update for %s",
-		sb.Component, impl.joinVersion(sb),
+	title := fmt.Sprintf("%s: %s update for %s",
+		impl.cfg.Title, sb.Component, impl.joinVersion(sb),
 	)
 	return DocumentTitle{
 		XmlLang:       "en",
@@ -72,35 +150,51 @@ func (impl bulletinImpl) documentPublisher() DocumentPublisher {
 }
 
 func (impl bulletinImpl) documentTracking(sb *domain.SecurityBulletin) DocumentTracking {
+	revisions := make([]Revision, len(sb.Revisions))
+	for k, v := range sb.Revisions {
+		revisions[k] = Revision{
+			Number:      cvrfVersion(v.Number),
+			Date:        v.Date,
+			Description: v.Description,
+		}
+	}
+
 	return DocumentTracking{
 		Identification: Identification{
 			Id: sb.Identification,
 		},
-		Status:  "Final",
-		Version: "1.0",
+		Status:  documentStatus(sb),
+		Version: cvrfVersion(sb.Version()),
 		RevisionHistory: RevisionHistory{
-			Revision: []Revision{{
-				Number:      "1.0",
-				Date:        sb.Date,
-				Description: "Initial",
-			}},
+			Revision: revisions,
 		},
-		InitialReleaseDate: sb.Date,
+		InitialReleaseDate: sb.InitialDate(),
 		CurrentReleaseDate: sb.Date,
 		Generator: Generator{
-			Engine: "openEuler BA Tool V1.0",
+			Engine: fmt.Sprintf("%s V%s", impl.cfg.EngineName, impl.cfg.EngineVersion),
 			Date:   sb.Date,
 		},
 	}
 }
 
-func (impl bulletinImpl) documentNotes(sb *domain.SecurityBulletin) DocumentNotes {
-	var description string
+// documentStatus is Interim for the withdrawn bulletin, because there is no withdrawn status in cvrf
+func documentStatus(sb *domain.SecurityBulletin) string {
+	if sb.Withdrawn {
+		return "Interim"
+	}
+
+	return "Final"
+}
+
+func cvrfVersion(n int) string {
+	return fmt.Sprintf("%d.0", n)
+}
+
+// severity chooses the highest security level in defects, as security level in bulletin
+func (impl bulletinImpl) severity(sb *domain.SecurityBulletin) string {
 	var highestLevelIndex int
 
 	for _, defect := range sb.Defects {
-		description += fmt.Sprintf("%s(%s)\r\n\r\n", defect.Description, impl.bugID(defect.Issue.Number))
-		// Choose the highest security level in defects, as security level in bulletin
 		for k, v := range dp.SequenceSeverityLevel {
 			if v == defect.SeverityLevel.String() && k > highestLevelIndex {
 				highestLevelIndex = k
@@ -108,45 +202,93 @@ func (impl bulletinImpl) documentNotes(sb *domain.SecurityBulletin) DocumentNote
 		}
 	}
 
-	return DocumentNotes{
-		Note: []Note{
-			{
-				Title:   "Synopsis",
-				Type:    "General",
-				Ordinal: "1",
-				XmlLang: "en",
-				Note:    fmt.Sprintf("%s bug update", sb.Component),
-			},
-			{
-				Title:   "Summary",
-				Type:    "General",
-				Ordinal: "2",
-				XmlLang: "en",
-				Note:    fmt.Sprintf("openEuler Bugfix Update for %s", impl.joinVersion(sb)),
-			},
-			{
-				Title:   "Description",
-				Type:    "General",
-				Ordinal: "3",
-				XmlLang: "en",
-				Note:    strings.Trim(description, "\r\n\r\n"),
-			},
-			{
-				Title:   "Severity",
-				Type:    "General",
-				Ordinal: "5",
-				XmlLang: "en",
-				Note:    dp.SequenceSeverityLevel[highestLevelIndex],
-			},
-			{
-				Title:   "Affected Component",
-				Type:    "General",
-				Ordinal: "6",
-				XmlLang: "en",
-				Note:    sb.Component,
-			},
+	return dp.SequenceSeverityLevel[highestLevelIndex]
+}
+
+// documentDescription joins the descriptions of defects, the English one is
+// returned only if all the defects are described in English
+func (impl bulletinImpl) documentDescription(sb *domain.SecurityBulletin) (zh, en string) {
+	translated := true
+	for _, defect := range sb.Defects {
+		bugID := impl.bugID(&defect)
+
+		zh += fmt.Sprintf("%s(%s)\r\n\r\n", defect.Description, bugID)
+		en += fmt.Sprintf("%s(%s)\r\n\r\n", defect.DescriptionEn, bugID)
+
+		if defect.DescriptionEn == "" {
+			translated = false
+		}
+	}
+
+	if !translated {
+		en = ""
+	}
+
+	return strings.Trim(zh, "\r\n\r\n"), strings.Trim(en, "\r\n\r\n")
+}
+
+func (impl bulletinImpl) documentNotes(sb *domain.SecurityBulletin) DocumentNotes {
+	notes := []Note{
+		{
+			Title:   "Synopsis",
+			Type:    "General",
+			Ordinal: "1",
+			XmlLang: langEn,
+			Note:    fmt.Sprintf("%s bug update", sb.Component),
+		},
+		{
+			Title:   "Summary",
+			Type:    "General",
+			Ordinal: "2",
+			XmlLang: langEn,
+			Note:    impl.summary(sb),
 		},
 	}
+
+	for _, t := range impl.localize(impl.documentDescription(sb)) {
+		notes = append(notes, Note{
+			Title:   "Description",
+			Type:    "General",
+			Ordinal: "3",
+			XmlLang: t.lang,
+			Note:    t.text,
+		})
+	}
+
+	notes = append(notes,
+		Note{
+			Title:   "Severity",
+			Type:    "General",
+			Ordinal: "5",
+			XmlLang: langEn,
+			Note:    impl.severity(sb),
+		},
+		Note{
+			Title:   "Affected Component",
+			Type:    "General",
+			Ordinal: "6",
+			XmlLang: langEn,
+			Note:    sb.Component,
+		},
+	)
+
+	return DocumentNotes{Note: notes}
+}
+
+// vulnerabilityNotes are the descriptions of the defect in the languages it is described in
+func (impl bulletinImpl) vulnerabilityNotes(defect *domain.Defect) CveNotes {
+	var notes []CveNote
+	for _, t := range impl.localize(defect.Description, defect.DescriptionEn) {
+		notes = append(notes, CveNote{
+			Title:   "Vulnerability Description",
+			Type:    "General",
+			Ordinal: "1",
+			XmlLang: t.lang,
+			Note:    t.text,
+		})
+	}
+
+	return CveNotes{CveNote: notes}
 }
 
 func (impl bulletinImpl) documentReferences(sb *domain.SecurityBulletin) DocumentReferences {
@@ -164,38 +306,67 @@ func (impl bulletinImpl) documentReferences(sb *domain.SecurityBulletin) Documen
 		defectUrl = append(defectUrl, CveUrl{Url: url})
 	}
 
-	return DocumentReferences{
-		CveReference: []CveReference{
-			{
-				Type:   "Self",
-				CveUrl: selfUrl,
-			},
-			{
-				Type:   "openEuler Bugfix",
-				CveUrl: defectUrl,
-			},
+	references := []CveReference{
+		{
+			Type:   "Self",
+			CveUrl: selfUrl,
+		},
+		{
+			Type:   impl.cfg.BugfixName,
+			CveUrl: defectUrl,
 		},
 	}
-}
 
-func (impl bulletinImpl) productTree(sb *domain.SecurityBulletin) ProductTree {
-	getCpe := func(v string) string {
-		t := strings.Split(v, "-")
-		return fmt.Sprintf("cpe:/a:%v:%v:%v", t[0], t[0], strings.Join(t[1:], "-"))
+	toCveUrl := func(urls []string) []CveUrl {
+		v := make([]CveUrl, len(urls))
+		for k, url := range urls {
+			v[k] = CveUrl{Url: url}
+		}
+
+		return v
 	}
 
+	refs := newDefectReferences(sb)
+	for _, v := range []struct {
+		t    string
+		urls []string
+	}{
+		{t: "Reference", urls: refs.reference},
+		{t: "Guidance", urls: refs.guidance},
+		{t: "Pull Request", urls: refs.pullRequest},
+	} {
+		if len(v.urls) > 0 {
+			references = append(references, CveReference{
+				Type:   v.t,
+				CveUrl: toCveUrl(v.urls),
+			})
+		}
+	}
+
+	return DocumentReferences{
+		CveReference: references,
+	}
+}
+
+// cpe converts the version such as openEuler-22.03-LTS to cpe:/a:openEuler:openEuler:22.03-LTS
+func cpe(v string) string {
+	t := strings.Split(v, "-")
+	return fmt.Sprintf("cpe:/a:%v:%v:%v", t[0], t[0], strings.Join(t[1:], "-"))
+}
+
+func (impl bulletinImpl) productTree(sb *domain.SecurityBulletin) ProductTree {
 	var productOfVersion []FullProductName
 	for _, v := range sb.AffectedVersion {
 		productOfVersion = append(productOfVersion, FullProductName{
 			ProductId:       v.String(),
-			Cpe:             getCpe(v.String()),
+			Cpe:             cpe(v.String()),
 			FullProductName: v.String(),
 		})
 	}
 
 	branchOfVersion := OpenEulerBranch{
 		Type:            "Product Name",
-		Name:            "openEuler",
+		Name:            impl.cfg.ProductName,
 		FullProductName: productOfVersion,
 	}
 
@@ -203,12 +374,14 @@ func (impl bulletinImpl) productTree(sb *domain.SecurityBulletin) ProductTree {
 		branchOfVersion,
 	}
 
-	for arch, products := range sb.ProductTree {
+	for _, arch := range sortedArches(sb) {
+		products := sb.ProductTree[arch]
+
 		var productOfArch []FullProductName
 		for _, p := range products {
 			productOfArch = append(productOfArch, FullProductName{
 				ProductId:       p.ID,
-				Cpe:             getCpe(p.CPE),
+				Cpe:             cpe(p.CPE),
 				FullProductName: p.FullName,
 			})
 		}
@@ -232,33 +405,13 @@ func (impl bulletinImpl) vulnerability(sb *domain.SecurityBulletin) []Vulnerabil
 	var vs []Vulnerability
 
 	for k, defect := range sb.Defects {
-		var idOfStatus []ProductId
-		for _, v := range sb.AffectedVersion {
-			idOfStatus = append(idOfStatus, ProductId{
-				ProductId: v.String(),
-			})
-		}
-
 		vul := Vulnerability{
-			Ordinal: strconv.Itoa(k + 1),
-			Xmlns:   impl.cfg.Xmlns,
-			CveNotes: CveNotes{
-				CveNote: CveNote{
-					Title:   "Vulnerability Description",
-					Type:    "General",
-					Ordinal: "1",
-					XmlLang: "en",
-					Note:    defect.Description,
-				},
-			},
-			ReleaseDate: sb.Date,
-			Bug:         impl.bugID(defect.Issue.Number),
-			ProductStatuses: ProductStatuses{
-				Status: Status{
-					Type:      "Fixed",
-					ProductId: idOfStatus,
-				},
-			},
+			Ordinal:         strconv.Itoa(k + 1),
+			Xmlns:           impl.cfg.Xmlns,
+			CveNotes:        impl.vulnerabilityNotes(&defect),
+			ReleaseDate:     defect.AcceptedDate(),
+			Bug:             impl.bugID(&defect),
+			ProductStatuses: impl.productStatuses(sb, &defect),
 			Threats: Threats{
 				Threat: Threat{
 					Type:        "Impact",
@@ -281,6 +434,42 @@ func (impl bulletinImpl) vulnerability(sb *domain.SecurityBulletin) []Vulnerabil
 	return vs
 }
 
-func (impl bulletinImpl) bugID(issueNumber string) string {
-	return fmt.Sprintf("BUG-%d-%s", utils.Year(), issueNumber)
+// productStatuses marks the products affected by the defect as Fixed, and the others as Known Not Affected
+func (impl bulletinImpl) productStatuses(sb *domain.SecurityBulletin, defect *domain.Defect) ProductStatuses {
+	fixed, notAffected := productStatus(sb, defect, func(p domain.Product) string {
+		return p.ID
+	})
+
+	toProductIds := func(ids []string) []ProductId {
+		v := make([]ProductId, len(ids))
+		for k, id := range ids {
+			v[k] = ProductId{ProductId: id}
+		}
+
+		return v
+	}
+
+	statuses := []Status{{
+		Type:      "Fixed",
+		ProductId: toProductIds(fixed),
+	}}
+
+	if len(notAffected) > 0 {
+		statuses = append(statuses, Status{
+			Type:      "Known Not Affected",
+			ProductId: toProductIds(notAffected),
+		})
+	}
+
+	return ProductStatuses{
+		Status: statuses,
+	}
+}
+
+// bugID is in the year when the defect was accepted, so that it does not change
+// no matter when the bulletin is released or re-issued
+func (impl bulletinImpl) bugID(defect *domain.Defect) string {
+	year := strings.SplitN(defect.AcceptedDate(), "-", 2)[0]
+
+	return fmt.Sprintf(impl.cfg.BugIdFormat, year, defect.Issue.Number)
 }
@@ -0,0 +1,325 @@
+package bulletinimpl
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+// run `go test ./defect/infrastructure/bulletinimpl -update` to regenerate the golden files
+var update = flag.Bool("update", false, "update the golden files")
+
+const (
+	version2003 = "openEuler-20.03-LTS-SP4"
+	version2203 = "openEuler-22.03-LTS-SP3"
+	date        = "2024-03-01"
+)
+
+func newTestBulletinImpl() bulletinImpl {
+	cfg := new(Config)
+	cfg.SetDefault()
+
+	if err := Init(cfg); err != nil {
+		panic(err)
+	}
+
+	return *instance
+}
+
+func systemVersions(vs ...string) []dp.SystemVersion {
+	versions := make([]dp.SystemVersion, len(vs))
+	for k, v := range vs {
+		versions[k], _ = dp.NewSystemVersion(v)
+	}
+
+	return versions
+}
+
+func severityLevel(s string) dp.SeverityLevel {
+	v, _ := dp.NewSeverityLevel(s)
+
+	return v
+}
+
+func url(s string) dp.URL {
+	v, _ := dp.NewURL(s)
+
+	return v
+}
+
+// acceptedAt is in the middle of the day, so that the date does not depend on the time zone
+func acceptedAt(date string) int64 {
+	t, _ := time.Parse("2006-01-02", date)
+
+	return t.Add(12 * time.Hour).Unix()
+}
+
+func testDefect(number, severity string, versions ...string) domain.Defect {
+	return domain.Defect{
+		Kernel:           "Linux 5.10.0",
+		Component:        "zbar",
+		ComponentVersion: "0.22",
+		SystemVersion:    systemVersions(versions[0])[0],
+		Description:      "crash when scanning the image of " + number,
+		ReferenceURL:     url("https://github.com/mchehab/zbar/issues/" + number),
+		GuidanceURL:      url("https://github.com/mchehab/zbar/wiki/" + number),
+		Influence:        "the process exits",
+		SeverityLevel:    severityLevel(severity),
+		AffectedVersion:  systemVersions(versions...),
+		Issue: domain.Issue{
+			Title:  "zbar crashes " + number,
+			Number: number,
+			Org:    "src-openeuler",
+			Repo:   "zbar",
+			Status: dp.IssueStatusClosed,
+		},
+		AcceptedAt: acceptedAt("2024-02-20"),
+	}
+}
+
+func product(version, rpm, id string) domain.Product {
+	return domain.Product{
+		ID:       id,
+		CPE:      version,
+		FullName: rpm,
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	impl := newTestBulletinImpl()
+
+	withPR := testDefect("I8ABCD", "Low", version2203)
+	withPR.MergedPR = []domain.PullRequest{{
+		Version: systemVersions(version2203)[0],
+		URL:     url("https://gitee.com/src-openeuler/zbar/pulls/12"),
+	}}
+
+	revised := domain.SecurityBulletin{
+		AffectedVersion: systemVersions(version2203),
+		Identification:  "cvrf-openEuler-BA-2024-1003",
+		Date:            date,
+		Component:       "zbar",
+		Grouping:        domain.GroupingSeparated,
+		Revisions:       domain.InitialRevisions(date),
+		ProductTree: domain.ProductTree{
+			dp.NewArch("src"): {product(version2203, "zbar-0.22-5.oe2203sp3.src.rpm", "zbar-0.22-5")},
+		},
+		Defects: domain.Defects{testDefect("I8AAAA", "Moderate", version2203)},
+	}
+	if err := revised.AddRevision("add the build of riscv64", "2024-03-15"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the BUG ID is in the year when the defect was accepted rather than released
+	lastYear := testDefect("I7ZZZZ", "Moderate", version2003, version2203)
+	lastYear.AcceptedAt = acceptedAt("2023-12-28")
+
+	translated := func(number string) domain.Defect {
+		d := testDefect(number, "Moderate", version2203)
+		d.Description = "扫描 " + number + " 的图片时崩溃"
+		d.DescriptionEn = "crash when scanning the image of " + number
+
+		return d
+	}
+
+	cases := []struct {
+		name     string
+		bulletin domain.SecurityBulletin
+	}{
+		{
+			name: "combined",
+			bulletin: domain.SecurityBulletin{
+				AffectedVersion: systemVersions(version2003, version2203),
+				Identification:  "cvrf-openEuler-BA-2024-1001",
+				Date:            date,
+				Component:       "zbar",
+				Grouping:        domain.GroupingCombined,
+				Revisions:       domain.InitialRevisions(date),
+				ProductTree: domain.ProductTree{
+					dp.NewArch("aarch64"): {
+						product(version2003, "zbar-0.22-4.oe2003sp4.aarch64.rpm", "zbar-0.22-4"),
+						product(version2203, "zbar-0.22-5.oe2203sp3.aarch64.rpm", "zbar-0.22-5"),
+					},
+					dp.NewArch("x86_64"): {
+						product(version2003, "zbar-0.22-4.oe2003sp4.x86_64.rpm", "zbar-0.22-4"),
+						product(version2203, "zbar-0.22-5.oe2203sp3.x86_64.rpm", "zbar-0.22-5"),
+					},
+					dp.NewArch("src"): {
+						product(version2003, "zbar-0.22-4.oe2003sp4.src.rpm", "zbar-0.22-4"),
+						product(version2203, "zbar-0.22-5.oe2203sp3.src.rpm", "zbar-0.22-5"),
+					},
+				},
+				Defects: domain.Defects{
+					lastYear,
+					testDefect("I8ABCE", "High", version2003, version2203),
+				},
+			},
+		},
+		{
+			name: "separated",
+			bulletin: domain.SecurityBulletin{
+				AffectedVersion: systemVersions(version2203),
+				Identification:  "cvrf-openEuler-BA-2024-1002",
+				Date:            date,
+				Component:       "zbar",
+				Grouping:        domain.GroupingSeparated,
+				Revisions:       domain.InitialRevisions(date),
+				ProductTree: domain.ProductTree{
+					dp.NewArch("x86_64"): {product(version2203, "zbar-0.22-5.oe2203sp3.x86_64.rpm", "zbar-0.22-5")},
+					dp.NewArch("src"):    {product(version2203, "zbar-0.22-5.oe2203sp3.src.rpm", "zbar-0.22-5")},
+				},
+				Defects: domain.Defects{withPR},
+			},
+		},
+		{
+			name: "mixed_versions",
+			bulletin: domain.SecurityBulletin{
+				AffectedVersion: systemVersions(version2003, version2203),
+				Identification:  "cvrf-openEuler-BA-2024-1004",
+				Date:            date,
+				Component:       "zbar",
+				Grouping:        domain.GroupingCombined,
+				Revisions:       domain.InitialRevisions(date),
+				ProductTree: domain.ProductTree{
+					dp.NewArch("noarch"): {
+						product(version2003, "zbar-help-0.22-4.oe2003sp4.noarch.rpm", "zbar-help-0.22-4"),
+						product(version2203, "zbar-help-0.22-5.oe2203sp3.noarch.rpm", "zbar-help-0.22-5"),
+					},
+					dp.NewArch("aarch64"): {
+						product(version2003, "zbar-0.22-4.oe2003sp4.aarch64.rpm", "zbar-0.22-4"),
+						product(version2203, "zbar-0.22-5.oe2203sp3.aarch64.rpm", "zbar-0.22-5"),
+					},
+				},
+				Defects: domain.Defects{
+					testDefect("I8BBBB", "Critical", version2203),
+					testDefect("I8CCCC", "Low", version2003, version2203),
+				},
+			},
+		},
+		{
+			name:     "revised",
+			bulletin: revised,
+		},
+		{
+			name: "bilingual",
+			bulletin: domain.SecurityBulletin{
+				AffectedVersion: systemVersions(version2203),
+				Identification:  "cvrf-openEuler-BA-2024-1006",
+				Date:            date,
+				Component:       "zbar",
+				Grouping:        domain.GroupingSeparated,
+				Revisions:       domain.InitialRevisions(date),
+				ProductTree: domain.ProductTree{
+					dp.NewArch("src"): {product(version2203, "zbar-0.22-5.oe2203sp3.src.rpm", "zbar-0.22-5")},
+				},
+				Defects: domain.Defects{translated("I8DDDD"), translated("I8EEEE")},
+			},
+		},
+	}
+
+	// each bulletin is rendered in cvrf and csaf, the ids of products in the csaf are checked
+	// instead of the structure, because the csaf is not parsed by the structure checker
+	renderers := []struct {
+		ext      string
+		generate func(*domain.SecurityBulletin) ([]byte, error)
+		check    func([]byte) error
+	}{
+		{ext: ".xml", generate: impl.Generate, check: StructureChecker().Check},
+		{ext: ".json", generate: CsafInstance().Generate, check: checkCsafProductIds},
+	}
+
+	for _, c := range cases {
+		for _, r := range renderers {
+			t.Run(c.name+r.ext, func(t *testing.T) {
+				got, err := r.generate(&c.bulletin)
+				if err != nil {
+					t.Fatalf("generate error: %s", err.Error())
+				}
+
+				if err = r.check(got); err != nil {
+					t.Errorf("invalid bulletin: %s", err.Error())
+				}
+
+				golden := filepath.Join("testdata", c.name+r.ext)
+				if *update {
+					if err = os.WriteFile(golden, got, 0644); err != nil {
+						t.Fatalf("update golden file error: %s", err.Error())
+					}
+				}
+
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("read golden file error: %s", err.Error())
+				}
+
+				if !bytes.Equal(got, want) {
+					t.Errorf("the bulletin differs from %s, run with -update if it is expected:\n%s", golden, got)
+				}
+			})
+		}
+	}
+}
+
+// TestGenerateOfFork makes sure that no name of openEuler is left in the bulletins of a fork
+func TestGenerateOfFork(t *testing.T) {
+	cfg := &Config{
+		IssuingAuthority:     "Fork security team",
+		IdentificationFormat: "cvrf-Fork-BA-%d-%d",
+		Title:                "Fork Bug Fix Advisory",
+		EngineName:           "Fork BA Tool",
+		ProductName:          "Fork",
+		SummaryFormat:        "Fork Bugfix Update for %s",
+		BugfixName:           "Fork Bugfix",
+	}
+	cfg.SetDefault()
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Init(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	defect := testDefect("I8ABCD", "Low", "Fork-22.03")
+	sb := domain.SecurityBulletin{
+		AffectedVersion: systemVersions("Fork-22.03"),
+		Identification:  "cvrf-Fork-BA-2024-1001",
+		Date:            date,
+		Component:       "zbar",
+		Revisions:       domain.InitialRevisions(date),
+		ProductTree: domain.ProductTree{
+			dp.NewArch("src"): {product("Fork-22.03", "zbar-0.22-5.fk2203.src.rpm", "zbar-0.22-5")},
+		},
+		Defects: domain.Defects{defect},
+	}
+
+	for _, g := range []interface {
+		Generate(*domain.SecurityBulletin) ([]byte, error)
+	}{instance, CsafInstance()} {
+		data, err := g.Generate(&sb)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(string(data), "Fork Bugfix Update for Fork-22.03") {
+			t.Errorf("the summary is not of the fork:\n%s", data)
+		}
+
+		if strings.Contains(string(data), "openEuler") {
+			t.Errorf("the name of openEuler is left in the bulletin:\n%s", data)
+		}
+	}
+
+	cfg.SummaryFormat = "Fork Bugfix Update"
+	if err := cfg.Validate(); err == nil {
+		t.Error("want error of the summary format without the versions")
+	}
+}
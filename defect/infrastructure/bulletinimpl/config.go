@@ -1,5 +1,28 @@
 package bulletinimpl
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/opensourceways/defect-manager/defect/domain/bulletin"
+)
+
+const (
+	langEn = "en"
+	langZh = "zh"
+)
+
+var (
+	validFormats = sets.NewString(
+		bulletin.FormatCVRF, bulletin.FormatCSAF, bulletin.FormatMarkdown, bulletin.FormatHTML,
+	)
+
+	validLanguages = sets.NewString(langEn, langZh)
+)
+
 type Config struct {
 	Xmlns                     string `json:"xmlns"`
 	XmlnsCvrf                 string `json:"xmlns_cvrf"`
@@ -7,6 +30,75 @@ type Config struct {
 	IssuingAuthority          string `json:"issuing_authority"`
 	SecurityBulletinUrlPrefix string `json:"security_bulletin_url_prefix"`
 	DefectUrlPrefix           string `json:"defect_url_prefix"`
+	CsafPublisherNamespace    string `json:"csaf_publisher_namespace"`
+
+	// IdentificationFormat has two %d, the year and the number of bulletin,
+	// such as cvrf-openEuler-BA-%d-%d
+	IdentificationFormat string `json:"identification_format"`
+	// StartNumber is the number before the first bulletin of each year
+	StartNumber int `json:"start_number"`
+	// BugIdFormat has two %s, the year and the number of issue, such as BUG-%s-%s
+	BugIdFormat   string `json:"bug_id_format"`
+	Title         string `json:"title"`
+	EngineName    string `json:"engine_name"`
+	EngineVersion string `json:"engine_version"`
+
+	// ProductName is the name of the distribution, which is the vendor and product of the product tree
+	ProductName string `json:"product_name"`
+	// SummaryFormat has one %s, the versions, such as openEuler Bugfix Update for %s
+	SummaryFormat string `json:"summary_format"`
+	// BugfixName is the type of the references to the issues and the system name of the BUG IDs
+	BugfixName string `json:"bugfix_name"`
+
+	// Formats are the formats of bulletin to upload, cvrf must be included
+	Formats []string `json:"formats"`
+
+	// MarkdownTemplate and HtmlTemplate are the paths of templates of the readable advisory,
+	// the default ones are used if they are not set
+	MarkdownTemplate string `json:"markdown_template"`
+	HtmlTemplate     string `json:"html_template"`
+
+	// PrimaryLanguage is the language of the notes which come first when
+	// the notes are written in both Chinese and English, en or zh
+	PrimaryLanguage string `json:"primary_language"`
+
+	// SigningKeyring is the path of the keyring which has the private key to sign the documents,
+	// the documents are not signed if it is not set
+	SigningKeyring string `json:"signing_keyring"`
+	// SigningKeyId is the key id of 16 hex characters or the fingerprint of the key,
+	// the first private key is used if it is not set
+	SigningKeyId      string `json:"signing_key_id"`
+	SigningPassphrase string `json:"signing_passphrase"`
+}
+
+func (c *Config) Validate() error {
+	for _, v := range c.Formats {
+		if !validFormats.Has(v) {
+			return fmt.Errorf("invalid bulletin format: %s", v)
+		}
+	}
+
+	if !sets.NewString(c.Formats...).Has(bulletin.FormatCVRF) {
+		return errors.New("bulletin format cvrf is required")
+	}
+
+	if _, err := newIdentifier(c.IdentificationFormat, c.StartNumber); err != nil {
+		return err
+	}
+
+	if strings.Count(c.BugIdFormat, "%") != 2 || strings.Count(c.BugIdFormat, "%s") != 2 {
+		return fmt.Errorf("the bug id format %s must have two %%s only", c.BugIdFormat)
+	}
+
+	if strings.Count(c.SummaryFormat, "%") != 1 || !strings.Contains(c.SummaryFormat, "%s") {
+		return fmt.Errorf("the summary format %s must have one %%s only", c.SummaryFormat)
+	}
+
+	if !validLanguages.Has(c.PrimaryLanguage) {
+		return fmt.Errorf("invalid primary language: %s", c.PrimaryLanguage)
+	}
+
+	return checkKeyId(c.SigningKeyId)
 }
 
 func (c *Config) SetDefault() {
@@ -33,4 +125,53 @@ func (c *Config) SetDefault() {
 	if c.DefectUrlPrefix == "" {
 		c.DefectUrlPrefix = "https://www.openeuler.org/en/security/cve/detail.html?id="
 	}
+
+	if c.CsafPublisherNamespace == "" {
+		c.CsafPublisherNamespace = "https://www.openeuler.org"
+	}
+
+	if c.IdentificationFormat == "" {
+		c.IdentificationFormat = "cvrf-openEuler-BA-%d-%d"
+	}
+
+	// the backend starts the bulletin number of each year from 1001
+	if c.StartNumber == 0 {
+		c.StartNumber = 1000
+	}
+
+	if c.BugIdFormat == "" {
+		c.BugIdFormat = "BUG-%s-%s"
+	}
+
+	if c.Title == "" {
+		c.Title = "openEuler Bug Fix Advisory"
+	}
+
+	if c.EngineName == "" {
+		c.EngineName = "openEuler BA Tool"
+	}
+
+	if c.EngineVersion == "" {
+		c.EngineVersion = "1.0"
+	}
+
+	if c.ProductName == "" {
+		c.ProductName = "openEuler"
+	}
+
+	if c.SummaryFormat == "" {
+		c.SummaryFormat = "openEuler Bugfix Update for %s"
+	}
+
+	if c.BugfixName == "" {
+		c.BugfixName = "openEuler Bugfix"
+	}
+
+	if len(c.Formats) == 0 {
+		c.Formats = []string{bulletin.FormatCVRF}
+	}
+
+	if c.PrimaryLanguage == "" {
+		c.PrimaryLanguage = langEn
+	}
 }
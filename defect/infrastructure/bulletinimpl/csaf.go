@@ -0,0 +1,254 @@
+package bulletinimpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+)
+
+var csafInstance *csafImpl
+
+func CsafInstance() *csafImpl {
+	return csafInstance
+}
+
+// csafImpl generates the bulletin in the format of CSAF 2.0 csaf_security_advisory
+type csafImpl struct {
+	bulletinImpl
+}
+
+func (impl csafImpl) Generate(sb *domain.SecurityBulletin) ([]byte, error) {
+	data := CsafBA{
+		Document:        impl.csafDocument(sb),
+		ProductTree:     impl.csafProductTree(sb),
+		Vulnerabilities: impl.csafVulnerabilities(sb),
+	}
+
+	return json.MarshalIndent(data, "", "\t")
+}
+
+// csafDate converts the date such as 2006-01-02 to the date time required by CSAF
+func csafDate(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// csafNotes makes a note of each language as the cvrf does, the title of note which is not
+// in the language of document is marked by its language, because the note of CSAF has no language.
+func (impl csafImpl) csafNotes(category, title string, texts []localizedText) []CsafNote {
+	notes := make([]CsafNote, len(texts))
+	for k, t := range texts {
+		notes[k] = CsafNote{
+			Category: category,
+			Title:    title,
+			Text:     t.text,
+		}
+
+		if t.lang != impl.cfg.PrimaryLanguage {
+			notes[k].Title = fmt.Sprintf("%s (%s)", title, t.lang)
+		}
+	}
+
+	return notes
+}
+
+func (impl csafImpl) csafDocument(sb *domain.SecurityBulletin) CsafDocument {
+	var references = []CsafReference{{
+		Category: "self",
+		Summary:  sb.Identification,
+		Url:      impl.cfg.SecurityBulletinUrlPrefix + sb.Identification,
+	}}
+	for _, defect := range sb.Defects {
+		references = append(references, CsafReference{
+			Category: "external",
+			Summary:  impl.cfg.BugfixName + " " + impl.bugID(&defect),
+			Url: fmt.Sprintf("https://gitee.com/%s/%s/issues/%s",
+				defect.Issue.Org, defect.Issue.Repo, defect.Issue.Number,
+			),
+		})
+	}
+
+	refs := newDefectReferences(sb)
+	for _, v := range []struct {
+		summary string
+		urls    []string
+	}{
+		{summary: "Reference", urls: refs.reference},
+		{summary: "Guidance", urls: refs.guidance},
+		{summary: "Pull Request", urls: refs.pullRequest},
+	} {
+		for _, url := range v.urls {
+			references = append(references, CsafReference{
+				Category: "external",
+				Summary:  v.summary,
+				Url:      url,
+			})
+		}
+	}
+
+	date := csafDate(sb.Date)
+
+	revisions := make([]CsafRevision, len(sb.Revisions))
+	for k, v := range sb.Revisions {
+		revisions[k] = CsafRevision{
+			Date:    csafDate(v.Date),
+			Number:  strconv.Itoa(v.Number),
+			Summary: v.Description,
+		}
+	}
+
+	notes := []CsafNote{
+		{
+			Category: "summary",
+			Title:    "Synopsis",
+			Text:     fmt.Sprintf("%s bug update", sb.Component),
+		},
+		{
+			Category: "general",
+			Title:    "Summary",
+			Text:     impl.summary(sb),
+		},
+	}
+	notes = append(notes, impl.csafNotes("description", "Description", impl.localize(impl.documentDescription(sb)))...)
+	notes = append(notes, CsafNote{
+		Category: "general",
+		Title:    "Affected Component",
+		Text:     sb.Component,
+	})
+
+	return CsafDocument{
+		Category:    "csaf_security_advisory",
+		CsafVersion: "2.0",
+		Title:       impl.documentTitle(sb).DocumentTitle,
+		Lang:        impl.cfg.PrimaryLanguage,
+		Publisher: CsafPublisher{
+			Category:         "vendor",
+			Name:             impl.cfg.IssuingAuthority,
+			Namespace:        impl.cfg.CsafPublisherNamespace,
+			ContactDetails:   impl.cfg.ContactDetails,
+			IssuingAuthority: impl.cfg.IssuingAuthority,
+		},
+		AggregateSeverity: CsafSeverity{
+			Text: impl.severity(sb),
+		},
+		Distribution: CsafDistribution{
+			Tlp: CsafTlp{Label: "WHITE"},
+		},
+		Notes:      notes,
+		References: references,
+		Tracking: CsafTracking{
+			Id:                 sb.Identification,
+			Status:             strings.ToLower(documentStatus(sb)),
+			Version:            strconv.Itoa(sb.Version()),
+			InitialReleaseDate: csafDate(sb.InitialDate()),
+			CurrentReleaseDate: date,
+			RevisionHistory:    revisions,
+			Generator: CsafGenerator{
+				Date: date,
+				Engine: CsafEngine{
+					Name:    impl.cfg.EngineName,
+					Version: impl.cfg.EngineVersion,
+				},
+			},
+		},
+	}
+}
+
+func (impl csafImpl) csafProductTree(sb *domain.SecurityBulletin) CsafProductTree {
+	var productOfVersion []CsafBranch
+	for _, v := range sb.AffectedVersion {
+		productOfVersion = append(productOfVersion, CsafBranch{
+			Category: "product_version",
+			Name:     v.String(),
+			Product: &CsafProduct{
+				Name:      v.String(),
+				ProductId: v.String(),
+				ProductIdentificationHelper: &CsafIdentificationHelper{
+					Cpe: cpe(v.String()),
+				},
+			},
+		})
+	}
+
+	branches := []CsafBranch{{
+		Category: "product_name",
+		Name:     impl.cfg.ProductName,
+		Branches: productOfVersion,
+	}}
+
+	for _, arch := range sortedArches(sb) {
+		var productOfArch []CsafBranch
+		for _, p := range sb.ProductTree[arch] {
+			productOfArch = append(productOfArch, CsafBranch{
+				Category: "product_version",
+				Name:     p.FullName,
+				Product: &CsafProduct{
+					Name:      p.FullName,
+					ProductId: p.FullName,
+				},
+			})
+		}
+
+		branches = append(branches, CsafBranch{
+			Category: "architecture",
+			Name:     arch.String(),
+			Branches: productOfArch,
+		})
+	}
+
+	return CsafProductTree{
+		Branches: []CsafBranch{{
+			Category: "vendor",
+			Name:     impl.cfg.ProductName,
+			Branches: branches,
+		}},
+	}
+}
+
+func (impl csafImpl) csafVulnerabilities(sb *domain.SecurityBulletin) []CsafVulnerability {
+	date := csafDate(sb.Date)
+
+	var vs []CsafVulnerability
+	for k := range sb.Defects {
+		defect := &sb.Defects[k]
+		fixed, notAffected := productStatus(sb, defect, func(p domain.Product) string {
+			return p.FullName
+		})
+
+		vs = append(vs, CsafVulnerability{
+			Ids: []CsafId{{
+				SystemName: impl.cfg.BugfixName,
+				Text:       impl.bugID(defect),
+			}},
+			Notes: impl.csafNotes(
+				"description", "Vulnerability Description", impl.localize(defect.Description, defect.DescriptionEn),
+			),
+			ReleaseDate: csafDate(defect.AcceptedDate()),
+			ProductStatus: CsafProductStatus{
+				Fixed:            fixed,
+				KnownNotAffected: notAffected,
+			},
+			Remediations: []CsafRemediation{{
+				Category:   "vendor_fix",
+				Details:    fmt.Sprintf("%s bug update", sb.Component),
+				Date:       date,
+				Url:        impl.cfg.SecurityBulletinUrlPrefix + sb.Identification,
+				ProductIds: fixed,
+			}},
+			Threats: []CsafThreat{{
+				Category: "impact",
+				Details:  defect.SeverityLevel.String(),
+			}},
+		})
+	}
+
+	return vs
+}
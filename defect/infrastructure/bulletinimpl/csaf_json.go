@@ -0,0 +1,131 @@
+package bulletinimpl
+
+type CsafBA struct {
+	Document        CsafDocument        `json:"document"`
+	ProductTree     CsafProductTree     `json:"product_tree"`
+	Vulnerabilities []CsafVulnerability `json:"vulnerabilities"`
+}
+
+type CsafDocument struct {
+	Category          string           `json:"category"`
+	CsafVersion       string           `json:"csaf_version"`
+	Title             string           `json:"title"`
+	Lang              string           `json:"lang"`
+	Publisher         CsafPublisher    `json:"publisher"`
+	AggregateSeverity CsafSeverity     `json:"aggregate_severity"`
+	Distribution      CsafDistribution `json:"distribution"`
+	Notes             []CsafNote       `json:"notes"`
+	References        []CsafReference  `json:"references"`
+	Tracking          CsafTracking     `json:"tracking"`
+}
+
+type CsafPublisher struct {
+	Category         string `json:"category"`
+	Name             string `json:"name"`
+	Namespace        string `json:"namespace"`
+	ContactDetails   string `json:"contact_details"`
+	IssuingAuthority string `json:"issuing_authority"`
+}
+
+type CsafSeverity struct {
+	Text string `json:"text"`
+}
+
+type CsafDistribution struct {
+	Tlp CsafTlp `json:"tlp"`
+}
+
+type CsafTlp struct {
+	Label string `json:"label"`
+}
+
+type CsafNote struct {
+	Category string `json:"category"`
+	Title    string `json:"title"`
+	Text     string `json:"text"`
+}
+
+type CsafReference struct {
+	Category string `json:"category"`
+	Summary  string `json:"summary"`
+	Url      string `json:"url"`
+}
+
+type CsafTracking struct {
+	Id                 string         `json:"id"`
+	Status             string         `json:"status"`
+	Version            string         `json:"version"`
+	InitialReleaseDate string         `json:"initial_release_date"`
+	CurrentReleaseDate string         `json:"current_release_date"`
+	RevisionHistory    []CsafRevision `json:"revision_history"`
+	Generator          CsafGenerator  `json:"generator"`
+}
+
+type CsafRevision struct {
+	Date    string `json:"date"`
+	Number  string `json:"number"`
+	Summary string `json:"summary"`
+}
+
+type CsafGenerator struct {
+	Date   string     `json:"date"`
+	Engine CsafEngine `json:"engine"`
+}
+
+type CsafEngine struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type CsafProductTree struct {
+	Branches []CsafBranch `json:"branches"`
+}
+
+type CsafBranch struct {
+	Category string       `json:"category"`
+	Name     string       `json:"name"`
+	Branches []CsafBranch `json:"branches,omitempty"`
+	Product  *CsafProduct `json:"product,omitempty"`
+}
+
+type CsafProduct struct {
+	Name                        string                    `json:"name"`
+	ProductId                   string                    `json:"product_id"`
+	ProductIdentificationHelper *CsafIdentificationHelper `json:"product_identification_helper,omitempty"`
+}
+
+type CsafIdentificationHelper struct {
+	Cpe string `json:"cpe"`
+}
+
+type CsafVulnerability struct {
+	Ids           []CsafId          `json:"ids"`
+	Notes         []CsafNote        `json:"notes"`
+	ReleaseDate   string            `json:"release_date"`
+	ProductStatus CsafProductStatus `json:"product_status"`
+	Remediations  []CsafRemediation `json:"remediations"`
+	Threats       []CsafThreat      `json:"threats"`
+}
+
+type CsafId struct {
+	SystemName string `json:"system_name"`
+	Text       string `json:"text"`
+}
+
+type CsafProductStatus struct {
+	Fixed            []string `json:"fixed,omitempty"`
+	KnownNotAffected []string `json:"known_not_affected,omitempty"`
+}
+
+type CsafRemediation struct {
+	Category   string   `json:"category"`
+	Details    string   `json:"details"`
+	Date       string   `json:"date"`
+	Url        string   `json:"url"`
+	ProductIds []string `json:"product_ids"`
+}
+
+type CsafThreat struct {
+	Category string `json:"category"`
+	Details  string `json:"details"`
+}
@@ -0,0 +1,71 @@
+package bulletinimpl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// checkCsafProductIds checks that the product ids are unique in the product tree,
+// and each one referred by the vulnerabilities is defined by the product tree
+func checkCsafProductIds(data []byte) error {
+	var doc CsafBA
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	var problems []string
+
+	products := sets.NewString()
+
+	var walk func([]CsafBranch)
+	walk = func(branches []CsafBranch) {
+		for _, b := range branches {
+			if b.Product != nil {
+				if products.Has(b.Product.ProductId) {
+					problems = append(problems, "duplicate product id: "+b.Product.ProductId)
+				}
+
+				products.Insert(b.Product.ProductId)
+			}
+
+			walk(b.Branches)
+		}
+	}
+	walk(doc.ProductTree.Branches)
+
+	check := func(vuln, field string, ids []string) {
+		for _, id := range ids {
+			if !products.Has(id) {
+				problems = append(problems, fmt.Sprintf("%s of %s is not in the product tree: %s", field, vuln, id))
+			}
+		}
+	}
+
+	if len(doc.Vulnerabilities) == 0 {
+		problems = append(problems, "no vulnerability")
+	}
+
+	for _, v := range doc.Vulnerabilities {
+		name := v.Ids[0].Text
+		if len(v.ProductStatus.Fixed) == 0 {
+			problems = append(problems, "no fixed product of "+name)
+		}
+
+		check(name, "fixed", v.ProductStatus.Fixed)
+		check(name, "known_not_affected", v.ProductStatus.KnownNotAffected)
+
+		for _, r := range v.Remediations {
+			check(name, "remediation", r.ProductIds)
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
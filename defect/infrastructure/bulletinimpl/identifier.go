@@ -0,0 +1,107 @@
+package bulletinimpl
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const verbOfNumber = "%d"
+
+// identifier formats the identifications by the format which has two %d, the year and the number
+type identifier struct {
+	prefix string
+	middle string
+	suffix string
+	start  int
+
+	// reg matches the whole identification
+	reg *regexp.Regexp
+	// regOfTail matches the year and number at the end of identification
+	regOfTail *regexp.Regexp
+	// regOfPreview matches the placeholder identification
+	regOfPreview *regexp.Regexp
+}
+
+func newIdentifier(format string, start int) (*identifier, error) {
+	if strings.Count(format, "%") != 2 || strings.Count(format, verbOfNumber) != 2 {
+		return nil, fmt.Errorf("the identification format %s must have two %s only", format, verbOfNumber)
+	}
+
+	parts := strings.Split(format, verbOfNumber)
+	if parts[1] == "" {
+		return nil, fmt.Errorf("the year and number of identification format %s must be separated", format)
+	}
+
+	if start < 0 {
+		return nil, errors.New("the start number of bulletin must not be negative")
+	}
+
+	tail := `(\d{4})` + regexp.QuoteMeta(parts[1]) + `(\d+)` + regexp.QuoteMeta(parts[2]) + `$`
+	preview := `\d{4}` + regexp.QuoteMeta(parts[1]) + `preview-\d+` + regexp.QuoteMeta(parts[2]) + `$`
+
+	id := &identifier{
+		prefix:       parts[0],
+		middle:       parts[1],
+		suffix:       parts[2],
+		start:        start,
+		reg:          regexp.MustCompile(`^` + regexp.QuoteMeta(parts[0]) + tail),
+		regOfTail:    regexp.MustCompile(tail),
+		regOfPreview: regexp.MustCompile(`^` + regexp.QuoteMeta(parts[0]) + preview),
+	}
+
+	// make sure that the identifications allocated can be parsed back
+	if year, num, err := id.Parse(id.Identification(2024, start+1)); err != nil || year != 2024 || num != start+1 {
+		return nil, fmt.Errorf("the identification format %s can not be parsed", format)
+	}
+
+	return id, nil
+}
+
+func (id *identifier) Identification(year, num int) string {
+	return fmt.Sprintf("%s%d%s%d%s", id.prefix, year, id.middle, num, id.suffix)
+}
+
+// Preview does not match the format, so that it can not be published by mistake
+func (id *identifier) Preview(year, index int) string {
+	return fmt.Sprintf("%s%d%spreview-%d%s", id.prefix, year, id.middle, index, id.suffix)
+}
+
+// Parse accepts the identification without the leading part, because the backend
+// returns it without the prefix of document, such as cvrf-
+func (id *identifier) Parse(identification string) (year, num int, err error) {
+	invalid := fmt.Errorf("invalid bulletin identification: %s", identification)
+
+	match := id.regOfTail.FindStringSubmatch(identification)
+	if len(match) != 3 {
+		return 0, 0, invalid
+	}
+
+	if year, err = strconv.Atoi(match[1]); err != nil {
+		return 0, 0, invalid
+	}
+
+	if num, err = strconv.Atoi(match[2]); err != nil {
+		return 0, 0, invalid
+	}
+
+	if !strings.HasSuffix(id.Identification(year, num), identification) {
+		return 0, 0, invalid
+	}
+
+	return
+}
+
+func (id *identifier) StartNumber() int {
+	return id.start
+}
+
+func (id *identifier) isValid(identification string) bool {
+	return id.reg.MatchString(identification)
+}
+
+func (id *identifier) isPreview(identification string) bool {
+	return id.regOfPreview.MatchString(identification)
+}
@@ -0,0 +1,57 @@
+package bulletinimpl
+
+import "testing"
+
+func TestNewIdentifier(t *testing.T) {
+	cases := []struct {
+		format string
+		valid  bool
+	}{
+		{format: "cvrf-openEuler-BA-%d-%d", valid: true},
+		{format: "cvrf-Fork-BA-%d.%d.x", valid: true},
+		{format: "cvrf-openEuler-BA-%d", valid: false},
+		{format: "cvrf-openEuler-BA-%d%d", valid: false},
+		{format: "cvrf-openEuler-BA-%d-%s", valid: false},
+		{format: "cvrf-%s-BA-%d-%d", valid: false},
+	}
+
+	for _, c := range cases {
+		if _, err := newIdentifier(c.format, 1000); (err == nil) != c.valid {
+			t.Errorf("format %s, want valid %v, got error %v", c.format, c.valid, err)
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	id, err := newIdentifier("cvrf-openEuler-BA-%d-%d", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identification := id.Identification(2024, 1001)
+	if identification != "cvrf-openEuler-BA-2024-1001" {
+		t.Errorf("unexpected identification: %s", identification)
+	}
+
+	if !id.isValid(identification) {
+		t.Errorf("%s should be valid", identification)
+	}
+
+	if preview := id.Preview(2024, 1); id.isValid(preview) || !id.isPreview(preview) {
+		t.Errorf("preview %s should not be valid", preview)
+	}
+
+	if id.isPreview(identification) {
+		t.Errorf("%s should not be a preview", identification)
+	}
+
+	// the backend returns the identification without the prefix of document
+	year, num, err := id.Parse("openEuler-BA-2023-1234")
+	if err != nil || year != 2023 || num != 1234 {
+		t.Errorf("unexpected result of parsing: %d %d %v", year, num, err)
+	}
+
+	if _, _, err = id.Parse("openEuler-SA-2023-1234"); err == nil {
+		t.Error("the identification of other format should not be parsed")
+	}
+}
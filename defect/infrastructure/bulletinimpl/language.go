@@ -0,0 +1,47 @@
+package bulletinimpl
+
+import "unicode"
+
+// localizedText is a text written in the language
+type localizedText struct {
+	lang string
+	text string
+}
+
+// localize returns the Chinese and English versions of a text which are not empty,
+// the one in the primary language comes first. The text which is not translated is
+// tagged by the language it is written in, because it is not always Chinese.
+func (impl bulletinImpl) localize(zh, en string) []localizedText {
+	if en == "" {
+		if zh == "" {
+			return nil
+		}
+
+		return []localizedText{{lang: detectLanguage(zh), text: zh}}
+	}
+
+	texts := []localizedText{{lang: langZh, text: zh}, {lang: langEn, text: en}}
+	if impl.cfg.PrimaryLanguage == langEn {
+		texts[0], texts[1] = texts[1], texts[0]
+	}
+
+	var ret []localizedText
+	for _, t := range texts {
+		if t.text != "" {
+			ret = append(ret, t)
+		}
+	}
+
+	return ret
+}
+
+// detectLanguage regards the text which has any Chinese character as Chinese, otherwise English
+func detectLanguage(s string) string {
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			return langZh
+		}
+	}
+
+	return langEn
+}
@@ -0,0 +1,63 @@
+package bulletinimpl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+)
+
+func TestLocalize(t *testing.T) {
+	impl := newTestBulletinImpl()
+
+	tests := []struct {
+		name string
+		zh   string
+		en   string
+		want []localizedText
+	}{
+		{"untranslated English", "crash", "", []localizedText{{langEn, "crash"}}},
+		{"untranslated Chinese", "崩溃 crash", "", []localizedText{{langZh, "崩溃 crash"}}},
+		{"English only", "", "crash", []localizedText{{langEn, "crash"}}},
+		{"translated", "崩溃", "crash", []localizedText{{langEn, "crash"}, {langZh, "崩溃"}}},
+		{"empty", "", "", nil},
+	}
+	for _, tt := range tests {
+		if got := impl.localize(tt.zh, tt.en); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: want %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestCsafNotes(t *testing.T) {
+	impl := newTestBulletinImpl()
+	impl.cfg.PrimaryLanguage = langZh
+	csaf := csafImpl{bulletinImpl: impl}
+
+	tests := []struct {
+		name string
+		zh   string
+		en   string
+		want []CsafNote
+	}{
+		{
+			"translated", "崩溃", "crash",
+			[]CsafNote{{"description", "Description", "崩溃"}, {"description", "Description (en)", "crash"}},
+		},
+		{
+			"untranslated", "crash", "",
+			[]CsafNote{{"description", "Description (en)", "crash"}},
+		},
+		{"empty", "", "", []CsafNote{}},
+	}
+	for _, tt := range tests {
+		got := csaf.csafNotes("description", "Description", csaf.localize(tt.zh, tt.en))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: want %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	if lang := csaf.csafDocument(&domain.SecurityBulletin{Revisions: domain.InitialRevisions(date)}).Lang; lang != langZh {
+		t.Errorf("want the language of document %s, got %s", langZh, lang)
+	}
+}
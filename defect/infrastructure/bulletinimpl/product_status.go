@@ -0,0 +1,75 @@
+package bulletinimpl
+
+import (
+	"sort"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+// productStatus splits the versions of bulletin and the products of them into the ones fixed by the defect
+// and the ones not affected by it, the CPE of product is the version it belongs to.
+// A product shared by several versions is fixed if any of them is affected by the defect.
+func productStatus(sb *domain.SecurityBulletin, defect *domain.Defect, productId func(domain.Product) string) (
+	fixed, notAffected []string,
+) {
+	affected := make(map[string]bool)
+	for _, v := range defect.AffectedVersion {
+		affected[v.String()] = true
+	}
+
+	fixedIds := make(map[string]bool)
+	for _, v := range sb.AffectedVersion {
+		if affected[v.String()] {
+			fixed = append(fixed, v.String())
+			fixedIds[v.String()] = true
+		}
+	}
+
+	arches := sortedArches(sb)
+	for _, arch := range arches {
+		for _, p := range sb.ProductTree[arch] {
+			if id := productId(p); affected[p.CPE] && !fixedIds[id] {
+				fixed = append(fixed, id)
+				fixedIds[id] = true
+			}
+		}
+	}
+
+	notAffectedIds := make(map[string]bool)
+	add := func(id string) {
+		if !fixedIds[id] && !notAffectedIds[id] {
+			notAffected = append(notAffected, id)
+			notAffectedIds[id] = true
+		}
+	}
+
+	for _, v := range sb.AffectedVersion {
+		if !affected[v.String()] {
+			add(v.String())
+		}
+	}
+
+	for _, arch := range arches {
+		for _, p := range sb.ProductTree[arch] {
+			if !affected[p.CPE] {
+				add(productId(p))
+			}
+		}
+	}
+
+	return
+}
+
+func sortedArches(sb *domain.SecurityBulletin) []dp.Arch {
+	arches := make([]dp.Arch, 0, len(sb.ProductTree))
+	for arch := range sb.ProductTree {
+		arches = append(arches, arch)
+	}
+
+	sort.Slice(arches, func(i, j int) bool {
+		return arches[i].String() < arches[j].String()
+	})
+
+	return arches
+}
@@ -0,0 +1,50 @@
+package bulletinimpl
+
+import (
+	"github.com/opensourceways/defect-manager/defect/domain"
+)
+
+// defectReferences are the urls collected for the defects of bulletin, the duplicated ones are removed
+type defectReferences struct {
+	reference   []string
+	guidance    []string
+	pullRequest []string
+}
+
+// newDefectReferences collects the reference and guidance urls of the defects,
+// and the merged pull requests of the versions affected by the bulletin
+func newDefectReferences(sb *domain.SecurityBulletin) defectReferences {
+	versions := make(map[string]bool)
+	for _, v := range sb.AffectedVersion {
+		versions[v.String()] = true
+	}
+
+	var refs defectReferences
+	seen := make(map[string]bool)
+	add := func(urls *[]string, url string) {
+		if url != "" && !seen[url] {
+			seen[url] = true
+			*urls = append(*urls, url)
+		}
+	}
+
+	for _, d := range sb.Defects {
+		if d.ReferenceURL != nil {
+			add(&refs.reference, d.ReferenceURL.URL())
+		}
+
+		if d.GuidanceURL != nil {
+			add(&refs.guidance, d.GuidanceURL.URL())
+		}
+	}
+
+	for _, d := range sb.Defects {
+		for _, pr := range d.MergedPR {
+			if pr.Version != nil && pr.URL != nil && versions[pr.Version.String()] {
+				add(&refs.pullRequest, pr.URL.URL())
+			}
+		}
+	}
+
+	return refs
+}
@@ -0,0 +1,94 @@
+package bulletinimpl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+
+	"github.com/opensourceways/defect-manager/defect/domain/bulletin"
+)
+
+// validKeyId is the long key id or the fingerprint, the short key id of 8 hex characters is
+// rejected because it is easy to make a key which has the same one
+var validKeyId = regexp.MustCompile(`^[0-9A-Fa-f]{16,}$`)
+
+var signerInstance *signer
+
+// Signer returns nil if the signing is not configured
+func Signer() bulletin.Signer {
+	if signerInstance == nil {
+		return nil
+	}
+
+	return signerInstance
+}
+
+// signer makes the OpenPGP detached signatures by the private key in the keyring
+type signer struct {
+	entity *openpgp.Entity
+}
+
+// newSigner loads the key from the keyring, which is either armored or binary.
+// The first private key is used if the key id is not set.
+func newSigner(keyring, keyId, passphrase string) (*signer, error) {
+	if err := checkKeyId(keyId); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(keyring)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err != nil {
+		if entities, err = openpgp.ReadKeyRing(bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("read keyring error: %s", err.Error())
+		}
+	}
+
+	for _, e := range entities {
+		if e.PrivateKey == nil {
+			continue
+		}
+
+		s := &signer{entity: e}
+		if keyId != "" && !strings.HasSuffix(s.Fingerprint(), strings.ToUpper(keyId)) {
+			continue
+		}
+
+		if err = e.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+			return nil, fmt.Errorf("decrypt signing key error: %s", err.Error())
+		}
+
+		return s, nil
+	}
+
+	return nil, errors.New("no signing key in the keyring")
+}
+
+func checkKeyId(keyId string) error {
+	if keyId != "" && !validKeyId.MatchString(keyId) {
+		return fmt.Errorf("invalid signing key id %s, it must be the fingerprint or the key id of 16 hex characters", keyId)
+	}
+
+	return nil
+}
+
+func (s *signer) Sign(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&buf, s.entity, bytes.NewReader(data), nil); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *signer) Fingerprint() string {
+	return fmt.Sprintf("%X", s.entity.PrimaryKey.Fingerprint)
+}
@@ -0,0 +1,85 @@
+package bulletinimpl
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// writeKeyring writes the armored private key of a new entity into a keyring file
+func writeKeyring(t *testing.T) (string, *openpgp.Entity) {
+	cfg := &packet.Config{RSABits: 1024}
+
+	e, err := openpgp.NewEntity("openEuler", "", "security@openeuler.org", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = e.SerializePrivate(w, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	w.Close()
+
+	keyring := filepath.Join(t.TempDir(), "keyring.asc")
+	if err = os.WriteFile(keyring, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return keyring, e
+}
+
+func TestSigner(t *testing.T) {
+	keyring, e := writeKeyring(t)
+
+	fingerprint := fmt.Sprintf("%X", e.PrimaryKey.Fingerprint)
+
+	s, err := newSigner(keyring, fingerprint[len(fingerprint)-16:], "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Fingerprint() != fingerprint {
+		t.Errorf("want fingerprint %s, got %s", fingerprint, s.Fingerprint())
+	}
+
+	data := []byte("<cvrfdoc/>")
+
+	sig, err := s.Sign(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = openpgp.CheckArmoredDetachedSignature(openpgp.EntityList{e}, bytes.NewReader(data), bytes.NewReader(sig), nil)
+	if err != nil {
+		t.Errorf("the signature is invalid: %s", err.Error())
+	}
+
+	_, err = openpgp.CheckArmoredDetachedSignature(
+		openpgp.EntityList{e}, bytes.NewReader([]byte("<cvrfdoc>")), bytes.NewReader(sig), nil,
+	)
+	if err == nil {
+		t.Error("the signature of the truncated file is valid")
+	}
+
+	if _, err = newSigner(keyring, "0123456789ABCDEF", ""); err == nil {
+		t.Error("want error of the key which is not in the keyring")
+	}
+
+	// the short key id matches the fingerprint, but it is too short to be trusted
+	if _, err = newSigner(keyring, fingerprint[len(fingerprint)-8:], ""); err == nil {
+		t.Error("want error of the short key id")
+	}
+}
@@ -0,0 +1,184 @@
+package bulletinimpl
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var (
+	// regOfVersion is the pattern of version and revision number defined by the cvrf 1.1 schema
+	regOfVersion = regexp.MustCompile(`^(0|[1-9][0-9]*)(\.(0|[1-9][0-9]*)){0,3}$`)
+	regOfOrdinal = regexp.MustCompile(`^[1-9][0-9]*$`)
+
+	publisherTypes   = sets.NewString("Vendor", "Discoverer", "Coordinator", "User", "Other")
+	documentStatuses = sets.NewString("Draft", "Interim", "Final")
+	noteTypes        = sets.NewString(
+		"General", "Details", "Description", "Summary", "FAQ", "Legal Disclaimer", "Other",
+	)
+	productStatusTypes = sets.NewString(
+		"First Affected", "Known Affected", "Known Not Affected", "First Fixed", "Fixed", "Recommended", "Last Affected",
+	)
+	threatTypes      = sets.NewString("Impact", "Exploit Status", "Target Set")
+	remediationTypes = sets.NewString("Workaround", "Mitigation", "Vendor Fix", "None Available", "Will Not Fix")
+)
+
+var structureCheckerInstance structureChecker
+
+// StructureChecker returns the checker of the generated cvrf documents
+func StructureChecker() structureChecker {
+	return structureCheckerInstance
+}
+
+// structureChecker is not a validation against the xsd of cvrf 1.1, and no schema is bundled.
+// The documents keep the layout of the bulletins published by openEuler, in which ProductTree and
+// Vulnerability are in the namespace of cvrf rather than the ones of prod and vuln, and Vulnerability
+// has the Bug element which the schema does not define, so the xsd would reject all of them. Instead, the elements and their order are fixed by the types of xml.go, and
+// it checks the well-formedness of document, the values which are restricted by the schema, such as
+// the types, dates and version numbers, and the references between the vulnerabilities and the
+// product tree, which the consumers of bulletins depend on.
+type structureChecker struct {
+	identifier *identifier
+}
+
+// Check returns all the problems of the document in one error
+func (v structureChecker) Check(data []byte) error {
+	return v.check(data, v.identifier.isValid)
+}
+
+// CheckPreview checks the document as Check does, except that the identification is the placeholder
+func (v structureChecker) CheckPreview(data []byte) error {
+	return v.check(data, v.identifier.isPreview)
+}
+
+func (v structureChecker) check(data []byte, validId func(string) bool) error {
+	var doc CvrfBA
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("malformed xml: %s", err.Error())
+	}
+
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	v.checkDocument(&doc, validId, check)
+	products := v.checkProductTree(&doc.ProductTree, check)
+	v.checkVulnerabilities(doc.Vulnerability, products, check)
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+type checkFunc func(ok bool, format string, args ...interface{})
+
+func isDate(s string) bool {
+	_, err := time.Parse("2006-01-02", s)
+
+	return err == nil
+}
+
+func isBlank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+func (v structureChecker) checkDocument(doc *CvrfBA, validId func(string) bool, check checkFunc) {
+	check(!isBlank(doc.DocumentTitle.DocumentTitle), "empty DocumentTitle")
+	check(!isBlank(doc.DocumentType), "empty DocumentType")
+	check(publisherTypes.Has(doc.DocumentPublisher.Type),
+		"invalid DocumentPublisher type: %s", doc.DocumentPublisher.Type,
+	)
+
+	tracking := &doc.DocumentTracking
+	check(validId(tracking.Identification.Id),
+		"invalid identification: %s", tracking.Identification.Id,
+	)
+	check(documentStatuses.Has(tracking.Status), "invalid document status: %s", tracking.Status)
+	check(regOfVersion.MatchString(tracking.Version), "invalid document version: %s", tracking.Version)
+	check(isDate(tracking.InitialReleaseDate), "invalid InitialReleaseDate: %s", tracking.InitialReleaseDate)
+	check(isDate(tracking.CurrentReleaseDate), "invalid CurrentReleaseDate: %s", tracking.CurrentReleaseDate)
+	check(len(tracking.RevisionHistory.Revision) > 0, "empty RevisionHistory")
+
+	for _, r := range tracking.RevisionHistory.Revision {
+		check(regOfVersion.MatchString(r.Number), "invalid revision number: %s", r.Number)
+		check(isDate(r.Date), "invalid date of revision %s: %s", r.Number, r.Date)
+		check(!isBlank(r.Description), "empty description of revision %s", r.Number)
+	}
+
+	for _, n := range doc.DocumentNotes.Note {
+		check(noteTypes.Has(n.Type), "invalid type of note %s: %s", n.Title, n.Type)
+		check(regOfOrdinal.MatchString(n.Ordinal), "invalid ordinal of note %s: %s", n.Title, n.Ordinal)
+		check(!isBlank(n.Note), "empty note: %s", n.Title)
+	}
+}
+
+// checkProductTree returns the ids of products defined in the tree
+func (v structureChecker) checkProductTree(tree *ProductTree, check checkFunc) sets.String {
+	products := sets.NewString()
+	hasPackage := false
+
+	for _, branch := range tree.OpenEulerBranch {
+		check(!isBlank(branch.Name), "empty name of branch")
+		check(len(branch.FullProductName) > 0, "empty branch: %s", branch.Name)
+
+		if branch.Type == "Package Arch" && len(branch.FullProductName) > 0 {
+			hasPackage = true
+		}
+
+		for _, p := range branch.FullProductName {
+			check(!isBlank(p.ProductId), "empty id of product: %s", p.FullProductName)
+			check(!isBlank(p.FullProductName), "empty name of product: %s", p.ProductId)
+
+			products.Insert(p.ProductId)
+		}
+	}
+
+	check(hasPackage, "no package in ProductTree")
+
+	return products
+}
+
+func (v structureChecker) checkVulnerabilities(vs []Vulnerability, products sets.String, check checkFunc) {
+	check(len(vs) > 0, "no Vulnerability")
+
+	for _, vul := range vs {
+		check(regOfOrdinal.MatchString(vul.Ordinal), "invalid ordinal of vulnerability: %s", vul.Ordinal)
+		check(len(vul.CveNotes.CveNote) > 0, "empty description of vulnerability %s", vul.Bug)
+
+		for _, n := range vul.CveNotes.CveNote {
+			check(!isBlank(n.Note), "empty description of vulnerability %s", vul.Bug)
+			check(noteTypes.Has(n.Type), "invalid type of note of vulnerability %s: %s", vul.Bug, n.Type)
+		}
+		check(len(vul.ProductStatuses.Status) > 0, "no product status of vulnerability %s", vul.Bug)
+
+		for _, s := range vul.ProductStatuses.Status {
+			check(productStatusTypes.Has(s.Type),
+				"invalid product status of vulnerability %s: %s", vul.Bug, s.Type,
+			)
+			check(len(s.ProductId) > 0, "empty product status %s of vulnerability %s", s.Type, vul.Bug)
+
+			for _, id := range s.ProductId {
+				check(products.Has(id.ProductId),
+					"product %s of vulnerability %s is not in ProductTree", id.ProductId, vul.Bug,
+				)
+			}
+		}
+
+		check(threatTypes.Has(vul.Threats.Threat.Type), "invalid threat of vulnerability %s: %s",
+			vul.Bug, vul.Threats.Threat.Type,
+		)
+		check(remediationTypes.Has(vul.Remediations.Remediation.Type),
+			"invalid remediation of vulnerability %s: %s", vul.Bug, vul.Remediations.Remediation.Type,
+		)
+	}
+}
@@ -0,0 +1,154 @@
+package bulletinimpl
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+func TestCheck(t *testing.T) {
+	impl := newTestBulletinImpl()
+
+	valid := func() domain.SecurityBulletin {
+		return domain.SecurityBulletin{
+			AffectedVersion: systemVersions(version2203),
+			Identification:  "cvrf-openEuler-BA-2024-1001",
+			Date:            date,
+			Component:       "zbar",
+			Revisions:       domain.InitialRevisions(date),
+			ProductTree: domain.ProductTree{
+				dp.NewArch("src"): {product(version2203, "zbar-0.22-5.oe2203sp3.src.rpm", "zbar-0.22-5")},
+			},
+			Defects: domain.Defects{testDefect("I8ABCD", "Low", version2203)},
+		}
+	}
+
+	preview := func(sb *domain.SecurityBulletin) { sb.Identification = "cvrf-openEuler-BA-2024-preview-1" }
+
+	cases := []struct {
+		name    string
+		modify  func(*domain.SecurityBulletin)
+		preview bool
+		want    string
+	}{
+		{
+			name:   "valid",
+			modify: func(sb *domain.SecurityBulletin) {},
+		},
+		{
+			name:   "empty product tree",
+			modify: func(sb *domain.SecurityBulletin) { sb.ProductTree = nil },
+			want:   "no package in ProductTree",
+		},
+		{
+			name:   "empty description",
+			modify: func(sb *domain.SecurityBulletin) { sb.Defects[0].Description = "" },
+			want:   "empty description of vulnerability",
+		},
+		{
+			name:   "no vulnerability",
+			modify: func(sb *domain.SecurityBulletin) { sb.Defects = nil },
+			want:   "no Vulnerability",
+		},
+		{
+			name:   "invalid identification",
+			modify: preview,
+			want:   "invalid identification",
+		},
+		{
+			name:    "preview",
+			modify:  preview,
+			preview: true,
+		},
+		{
+			name: "preview without product tree",
+			modify: func(sb *domain.SecurityBulletin) {
+				preview(sb)
+				sb.ProductTree = nil
+			},
+			preview: true,
+			want:    "no package in ProductTree",
+		},
+		{
+			name:    "preview of the identification allocated",
+			modify:  func(sb *domain.SecurityBulletin) {},
+			preview: true,
+			want:    "invalid identification",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sb := valid()
+			c.modify(&sb)
+
+			data, err := impl.Generate(&sb)
+			if err != nil {
+				t.Fatalf("generate error: %s", err.Error())
+			}
+
+			if c.preview {
+				err = StructureChecker().CheckPreview(data)
+			} else {
+				err = StructureChecker().Check(data)
+			}
+
+			switch {
+			case c.want == "" && err != nil:
+				t.Errorf("unexpected error: %s", err.Error())
+			case c.want != "" && (err == nil || !strings.Contains(err.Error(), c.want)):
+				t.Errorf("want error containing %q, got %v", c.want, err)
+			}
+		})
+	}
+}
+
+func TestCheckEscapedText(t *testing.T) {
+	impl := newTestBulletinImpl()
+
+	text := "overflow when a < b && c > d"
+
+	sb := domain.SecurityBulletin{
+		AffectedVersion: systemVersions(version2203),
+		Identification:  "cvrf-openEuler-BA-2024-1001",
+		Date:            date,
+		Component:       "zbar",
+		Revisions:       domain.InitialRevisions(date),
+		ProductTree: domain.ProductTree{
+			dp.NewArch("src"): {product(version2203, "zbar<&>-0.22-5.oe2203sp3.src.rpm", "zbar-0.22-5")},
+		},
+		Defects: domain.Defects{testDefect("I8ABCD", "Low", version2203)},
+	}
+	sb.Defects[0].Description = text
+	sb.Defects[0].Issue.Title = text
+
+	data, err := impl.Generate(&sb)
+	if err != nil {
+		t.Fatalf("generate error: %s", err.Error())
+	}
+
+	if !strings.Contains(string(data), "a &lt; b &amp;&amp; c &gt; d") {
+		t.Errorf("the text is not escaped:\n%s", data)
+	}
+
+	if err = StructureChecker().Check(data); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var doc CvrfBA
+	if err = xml.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if note := doc.Vulnerability[0].CveNotes.CveNote[0].Note; !strings.Contains(note, text) {
+		t.Errorf("want note containing %q, got %q", text, note)
+	}
+
+	if err = StructureChecker().Check([]byte("<cvrfdoc>a < b</cvrfdoc>")); err == nil ||
+		!strings.Contains(err.Error(), "malformed xml") {
+		t.Errorf("want error of malformed xml, got %v", err)
+	}
+}
@@ -19,7 +19,7 @@ type CvrfBA struct {
 type DocumentTitle struct {
 	XMLName       xml.Name `xml:"DocumentTitle,omitempty"`
 	XmlLang       string   `xml:"xml:lang,attr"`
-	DocumentTitle string   `xml:",innerxml"`
+	DocumentTitle string   `xml:",chardata"`
 }
 
 type DocumentPublisher struct {
@@ -74,7 +74,7 @@ type Note struct {
 	Type    string   `xml:"Type,attr"`
 	Ordinal string   `xml:"Ordinal,attr"`
 	XmlLang string   `xml:"xml:lang,attr"`
-	Note    string   `xml:",innerxml"`
+	Note    string   `xml:",chardata"`
 }
 
 type DocumentReferences struct {
@@ -90,7 +90,7 @@ type CveReference struct {
 
 type CveUrl struct {
 	XMLName xml.Name `xml:"URL,omitempty"`
-	Url     string   `xml:",innerxml"`
+	Url     string   `xml:",chardata"`
 }
 
 type ProductTree struct {
@@ -110,7 +110,7 @@ type FullProductName struct {
 	XMLName         xml.Name `xml:"FullProductName,omitempty"`
 	ProductId       string   `xml:"ProductID,attr"`
 	Cpe             string   `xml:"CPE,attr"`
-	FullProductName string   `xml:",innerxml"`
+	FullProductName string   `xml:",chardata"`
 }
 
 type Vulnerability struct {
@@ -126,8 +126,8 @@ type Vulnerability struct {
 }
 
 type CveNotes struct {
-	XMLName xml.Name `xml:"Notes,omitempty"`
-	CveNote CveNote  `xml:"Note,omitempty"`
+	XMLName xml.Name  `xml:"Notes,omitempty"`
+	CveNote []CveNote `xml:"Note,omitempty"`
 }
 
 type CveNote struct {
@@ -136,12 +136,12 @@ type CveNote struct {
 	Type    string   `xml:"Type,attr"`
 	Ordinal string   `xml:"Ordinal,attr"`
 	XmlLang string   `xml:"xml:lang,attr"`
-	Note    string   `xml:",innerxml"`
+	Note    string   `xml:",chardata"`
 }
 
 type ProductStatuses struct {
 	XMLName xml.Name `xml:"ProductStatuses,omitempty"`
-	Status  Status   `xml:"Status,omitempty"`
+	Status  []Status `xml:"Status,omitempty"`
 }
 
 type Status struct {
@@ -152,7 +152,7 @@ type Status struct {
 
 type ProductId struct {
 	XMLName   xml.Name `xml:"ProductID,omitempty"`
-	ProductId string   `xml:",innerxml"`
+	ProductId string   `xml:",chardata"`
 }
 
 type Threats struct {
@@ -0,0 +1,34 @@
+package gitimpl
+
+type Config struct {
+	// Repository is the url of the repository to clone, it can be the path of a local bare repository
+	Repository string `json:"repository" required:"true"`
+	// Directory is the working tree, the repository is cloned into it if it is not cloned yet
+	Directory   string `json:"directory"  required:"true"`
+	Branch      string `json:"branch"`
+	AuthorName  string `json:"author_name"`
+	AuthorEmail string `json:"author_email"`
+	// CommitMessage is the template of commit message, the data of it are Date and Files,
+	// which are the date of commit and the names of bulletins
+	CommitMessage string `json:"commit_message"`
+	// Push pushes the commits to the repository, they are kept in the working tree if it is false
+	Push bool `json:"push"`
+}
+
+func (c *Config) SetDefault() {
+	if c.Branch == "" {
+		c.Branch = "master"
+	}
+
+	if c.AuthorName == "" {
+		c.AuthorName = "defect-manager"
+	}
+
+	if c.AuthorEmail == "" {
+		c.AuthorEmail = "defect-manager@openeuler.org"
+	}
+
+	if c.CommitMessage == "" {
+		c.CommitMessage = "publish {{len .Files}} bulletins on {{.Date}}\n\n{{range .Files}}{{.}}\n{{end}}"
+	}
+}
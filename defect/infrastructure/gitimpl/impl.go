@@ -0,0 +1,200 @@
+package gitimpl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	storage "github.com/opensourceways/defect-manager/defect/domain/obs"
+	"github.com/opensourceways/defect-manager/utils"
+)
+
+// datedDir is the directory of date which the files are uploaded to
+var datedDir = regexp.MustCompile(`^(\d{4})-\d{2}-\d{2}/`)
+
+var instance *gitImpl
+
+// Init clones the repository into the working tree if it is not cloned yet,
+// the date of commit message is told by the clock
+func Init(cfg *Config, c utils.Clock) error {
+	tmpl, err := template.New("commit").Parse(cfg.CommitMessage)
+	if err != nil {
+		return fmt.Errorf("parse commit message template error: %s", err.Error())
+	}
+
+	impl := &gitImpl{
+		cfg:   cfg,
+		tmpl:  tmpl,
+		clock: c,
+	}
+
+	if _, err = os.Stat(filepath.Join(cfg.Directory, ".git")); errors.Is(err, fs.ErrNotExist) {
+		err = impl.clone()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	instance = impl
+
+	return nil
+}
+
+func Instance() *gitImpl {
+	return instance
+}
+
+// gitImpl writes the files into the working tree of a git repository in the layout of <year>/<file>,
+// the files are published when they are committed and pushed.
+type gitImpl struct {
+	cfg   *Config
+	tmpl  *template.Template
+	clock utils.Clock
+}
+
+func (impl *gitImpl) clone() error {
+	cmd := exec.Command("git", "clone", "-q", impl.cfg.Repository, impl.cfg.Directory)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git clone error: %s, %s", err.Error(), out)
+	}
+
+	// the branch does not exist when the repository is empty
+	remote := "origin/" + impl.cfg.Branch
+	if _, err := impl.git("rev-parse", "-q", "--verify", remote); err != nil {
+		_, err = impl.git("symbolic-ref", "HEAD", "refs/heads/"+impl.cfg.Branch)
+
+		return err
+	}
+
+	_, err := impl.git("checkout", "-q", "-B", impl.cfg.Branch, remote)
+
+	return err
+}
+
+func (impl *gitImpl) git(args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", impl.cfg.Directory}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+impl.cfg.AuthorName,
+		"GIT_AUTHOR_EMAIL="+impl.cfg.AuthorEmail,
+		"GIT_COMMITTER_NAME="+impl.cfg.AuthorName,
+		"GIT_COMMITTER_EMAIL="+impl.cfg.AuthorEmail,
+	)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s error: %s, %s", args[0], err.Error(), out)
+	}
+
+	return string(out), nil
+}
+
+// StoragePath maps the path of file to the one in the working tree,
+// the directory of date is replaced by the year of it.
+func (impl *gitImpl) StoragePath(p string) string {
+	return datedDir.ReplaceAllString(p, "$1/")
+}
+
+func (impl *gitImpl) treePath(p string) string {
+	return filepath.Join(impl.cfg.Directory, filepath.FromSlash(impl.StoragePath(p)))
+}
+
+func (impl *gitImpl) Upload(p string, data []byte) error {
+	file := impl.treePath(p)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, data, 0644)
+}
+
+func (impl *gitImpl) Download(p string) ([]byte, error) {
+	data, err := os.ReadFile(impl.treePath(p))
+	if errors.Is(err, fs.ErrNotExist) {
+		err = storage.ErrNotFound
+	}
+
+	return data, err
+}
+
+func (impl *gitImpl) List() ([]string, error) {
+	var paths []string
+
+	err := filepath.WalkDir(impl.cfg.Directory, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		rel, err := filepath.Rel(impl.cfg.Directory, file)
+		if err == nil {
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+
+		return err
+	})
+
+	return paths, err
+}
+
+// Commit commits all the changes of working tree, and pushes the commit if it is enabled
+func (impl *gitImpl) Commit(paths []string) error {
+	if _, err := impl.git("add", "-A"); err != nil {
+		return err
+	}
+
+	// nothing is committed when the files uploaded are the same as before
+	if status, err := impl.git("status", "--porcelain"); err != nil || status == "" {
+		return err
+	}
+
+	msg, err := impl.message(paths)
+	if err != nil {
+		return err
+	}
+
+	if _, err = impl.git("commit", "-q", "-m", msg); err != nil || !impl.cfg.Push {
+		return err
+	}
+
+	_, err = impl.git("push", "-q", "origin", "HEAD:refs/heads/"+impl.cfg.Branch)
+
+	return err
+}
+
+func (impl *gitImpl) message(paths []string) (string, error) {
+	files := make([]string, len(paths))
+	for k, v := range paths {
+		files[k] = path.Base(v)
+	}
+
+	data := struct {
+		Date  string
+		Files []string
+	}{
+		Date:  utils.ToDate(impl.clock.Now().Unix()),
+		Files: files,
+	}
+
+	var buf bytes.Buffer
+	if err := impl.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("execute commit message template error: %s", err.Error())
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,129 @@
+package gitimpl
+
+import (
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type clockTest struct {
+	now time.Time
+}
+
+func (c clockTest) Now() time.Time {
+	return c.now
+}
+
+func bareGit(t *testing.T, dir string, args ...string) string {
+	out, err := exec.Command("git", append([]string{"--git-dir", dir}, args...)...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v error: %s, %s", args, err.Error(), out)
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+func TestCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	remote := filepath.Join(dir, "advisories.git")
+
+	if out, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init error: %s, %s", err.Error(), out)
+	}
+
+	cfg := Config{
+		Repository:    remote,
+		Directory:     filepath.Join(dir, "tree"),
+		CommitMessage: "publish bulletins of {{.Date}}\n\n{{range .Files}}{{.}}\n{{end}}",
+		Push:          true,
+	}
+	cfg.SetDefault()
+
+	clock := clockTest{now: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)}
+
+	if err := Init(&cfg, clock); err != nil {
+		t.Fatal(err)
+	}
+
+	impl := Instance()
+
+	// the files are listed by the paths in the tree as the index does
+	files := map[string]string{
+		"2024-03-01/cvrf-openEuler-BA-2024-1001.xml": "<cvrfdoc/>",
+		"2024-03-01/update_defect.txt":               impl.StoragePath("2024-03-01/cvrf-openEuler-BA-2024-1001.xml"),
+		"index.txt":                                  impl.StoragePath("2024-03-01/cvrf-openEuler-BA-2024-1001.xml"),
+	}
+	for k, v := range files {
+		if err := impl.Upload(k, []byte(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := impl.Download("2024-03-01/cvrf-openEuler-BA-2024-1001.xml")
+	if err != nil || string(data) != "<cvrfdoc/>" {
+		t.Errorf("unexpected content: %s, %v", data, err)
+	}
+
+	if err = impl.Commit([]string{"2024-03-01/cvrf-openEuler-BA-2024-1001.xml"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// nothing is committed when nothing changes
+	if err = impl.Commit([]string{"2024-03-01/cvrf-openEuler-BA-2024-1001.xml"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := bareGit(t, remote, "rev-list", "--count", "master"); n != "1" {
+		t.Errorf("want 1 commit, got %s", n)
+	}
+
+	// the directory of date is replaced by the year
+	tree := strings.Split(bareGit(t, remote, "ls-tree", "-r", "--name-only", "master"), "\n")
+	want := []string{
+		"2024/cvrf-openEuler-BA-2024-1001.xml", "2024/update_defect.txt", "index.txt",
+	}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("want tree %v, got %v", want, tree)
+	}
+
+	// the files listed by the uploaded file and index are in the tree
+	for _, v := range []string{"2024/update_defect.txt", "index.txt"} {
+		for _, p := range strings.Split(bareGit(t, remote, "show", "master:"+v), "\n") {
+			if bareGit(t, remote, "ls-tree", "--name-only", "master", p) != p {
+				t.Errorf("%s of %s is not in the tree", p, v)
+			}
+		}
+	}
+
+	msg := bareGit(t, remote, "log", "-1", "--format=%B", "master")
+	if want := "publish bulletins of 2024-03-01\n\ncvrf-openEuler-BA-2024-1001.xml"; msg != want {
+		t.Errorf("want commit message %q, got %q", want, msg)
+	}
+
+	paths, err := impl.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("want paths %v, got %v", want, paths)
+	}
+
+	// the branch pushed is checked out when the repository is cloned again
+	cfg2 := cfg
+	cfg2.Directory = filepath.Join(dir, "another")
+	if err = Init(&cfg2, clock); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err = Instance().Download("index.txt"); err != nil || string(data) != files["index.txt"] {
+		t.Errorf("unexpected content: %s, %v", data, err)
+	}
+}
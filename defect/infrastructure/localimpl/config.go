@@ -0,0 +1,5 @@
+package localimpl
+
+type Config struct {
+	Directory string `json:"directory" required:"true"`
+}
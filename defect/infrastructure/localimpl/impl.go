@@ -0,0 +1,95 @@
+package localimpl
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	storage "github.com/opensourceways/defect-manager/defect/domain/obs"
+)
+
+var instance *localImpl
+
+func Init(cfg *Config) error {
+	if err := os.MkdirAll(cfg.Directory, 0755); err != nil {
+		return err
+	}
+
+	instance = &localImpl{
+		cfg: cfg,
+	}
+
+	return nil
+}
+
+func Instance() *localImpl {
+	return instance
+}
+
+// localImpl writes the files into a local directory in the same layout as obs,
+// so that the bulletins can be generated without any remote storage
+type localImpl struct {
+	cfg *Config
+}
+
+func (impl localImpl) Upload(p string, data []byte) error {
+	path := filepath.Join(impl.cfg.Directory, filepath.FromSlash(p))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so that a partial file is never seen
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (impl localImpl) Download(p string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(impl.cfg.Directory, filepath.FromSlash(p)))
+	if errors.Is(err, fs.ErrNotExist) {
+		err = storage.ErrNotFound
+	}
+
+	return data, err
+}
+
+func (impl localImpl) List() ([]string, error) {
+	var paths []string
+
+	err := filepath.WalkDir(impl.cfg.Directory, func(path string, d fs.DirEntry, err error) error {
+		// the temporary files are being uploaded
+		if err != nil || d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return err
+		}
+
+		rel, err := filepath.Rel(impl.cfg.Directory, path)
+		if err == nil {
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+
+		return err
+	})
+
+	return paths, err
+}
@@ -0,0 +1,84 @@
+package localimpl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	storage "github.com/opensourceways/defect-manager/defect/domain/obs"
+)
+
+func TestUpload(t *testing.T) {
+	cfg := Config{Directory: t.TempDir()}
+	if err := Init(&cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, content := range []string{"first", "second"} {
+		if err := Instance().Upload("2024-03-01/update_defect.txt", []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := filepath.Join(cfg.Directory, "2024-03-01")
+
+	data, err := os.ReadFile(filepath.Join(dir, "update_defect.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "second" {
+		t.Errorf("the file is not overwritten: %s", data)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("the temporary files are left: %v", entries)
+	}
+}
+
+func TestDownloadAndList(t *testing.T) {
+	cfg := Config{Directory: t.TempDir()}
+	if err := Init(&cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Instance().Download("index.txt"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("want ErrNotFound, got %v", err)
+	}
+
+	files := map[string]string{
+		"index.txt": "2024-03-01/cvrf-openEuler-BA-2024-1001.xml",
+		"2024-03-01/cvrf-openEuler-BA-2024-1001.xml": "<cvrfdoc/>",
+	}
+	for k, v := range files {
+		if err := Instance().Upload(k, []byte(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := Instance().Download("index.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != files["index.txt"] {
+		t.Errorf("unexpected content: %s", data)
+	}
+
+	paths, err := Instance().List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"2024-03-01/cvrf-openEuler-BA-2024-1001.xml", "index.txt"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("want %v, got %v", want, paths)
+	}
+}
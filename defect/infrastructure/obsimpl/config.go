@@ -1,9 +1,52 @@
 package obsimpl
 
+import (
+	"fmt"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-obs/obs"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var validSignatures = sets.NewString(
+	string(obs.SignatureV2), string(obs.SignatureV4), string(obs.SignatureObs),
+)
+
 type Config struct {
 	AccessKey string `json:"access_key"    required:"true"`
 	SecretKey string `json:"secret_key"    required:"true"`
 	Endpoint  string `json:"endpoint"      required:"true"`
 	Bucket    string `json:"bucket"        required:"true"`
 	Directory string `json:"directory"     required:"true"`
+	// Verify reads the file back after it is uploaded and compares the checksum of it
+	Verify bool `json:"verify"`
+	// Signature is the way of signing the requests, v2, v4 or OBS, the sdk chooses it if it is empty
+	Signature string `json:"signature"`
+	// Region is used by the signature v4
+	Region string `json:"region"`
+	// PathStyle puts the bucket in the path instead of the host, which is required by MinIO
+	PathStyle bool `json:"path_style"`
+}
+
+func (c *Config) Validate() error {
+	if c.Signature != "" && !validSignatures.Has(c.Signature) {
+		return fmt.Errorf("invalid signature: %s", c.Signature)
+	}
+
+	return nil
+}
+
+// S3Config is the config of S3 compatible storage, such as MinIO.
+// The obs sdk speaks the S3 protocol when it signs the requests by the AWS signature V4.
+type S3Config struct {
+	Config
+}
+
+func (c *S3Config) SetDefault() {
+	if c.Signature == "" {
+		c.Signature = string(obs.SignatureV4)
+	}
+
+	if c.Region == "" {
+		c.Region = "us-east-1"
+	}
 }
@@ -2,17 +2,25 @@ package obsimpl
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"io"
+	"path"
+	"strings"
 
 	"github.com/huaweicloud/huaweicloud-sdk-go-obs/obs"
 
-	"github.com/opensourceways/defect-manager/utils"
+	storage "github.com/opensourceways/defect-manager/defect/domain/obs"
 )
 
 var instance *obsImpl
 
 func Init(cfg *Config) error {
-	cli, err := obs.New(cfg.AccessKey, cfg.SecretKey, cfg.Endpoint)
+	cli, err := obs.New(cfg.AccessKey, cfg.SecretKey, cfg.Endpoint,
+		obs.WithSignature(obs.SignatureType(cfg.Signature)),
+		obs.WithRegion(cfg.Region),
+		obs.WithPathStyle(cfg.PathStyle),
+	)
 	if err != nil {
 		return err
 	}
@@ -34,13 +42,75 @@ type obsImpl struct {
 	cli *obs.ObsClient
 }
 
-func (impl obsImpl) Upload(fileName string, data []byte) error {
+func (impl obsImpl) Upload(p string, data []byte) error {
 	input := &obs.PutObjectInput{}
 	input.Bucket = impl.cfg.Bucket
-	input.Key = fmt.Sprintf("%s/%s/%s", impl.cfg.Directory, utils.Date(), fileName)
+	input.Key = path.Join(impl.cfg.Directory, p)
 	input.Body = bytes.NewReader(data)
 
-	_, err := impl.cli.PutObject(input)
+	if _, err := impl.cli.PutObject(input); err != nil || !impl.cfg.Verify {
+		return err
+	}
+
+	return impl.verify(p, data)
+}
+
+// verify reads the file back and compares the checksum of it, so that the truncated file is found
+func (impl obsImpl) verify(p string, data []byte) error {
+	v, err := impl.Download(p)
+	if err != nil {
+		return fmt.Errorf("read back %s error: %s", p, err.Error())
+	}
+
+	if sha256.Sum256(v) != sha256.Sum256(data) {
+		return fmt.Errorf("checksum mismatch of %s", p)
+	}
+
+	return nil
+}
+
+func (impl obsImpl) Download(p string) ([]byte, error) {
+	input := &obs.GetObjectInput{}
+	input.Bucket = impl.cfg.Bucket
+	input.Key = path.Join(impl.cfg.Directory, p)
 
-	return err
+	output, err := impl.cli.GetObject(input)
+	if err != nil {
+		if v, ok := err.(obs.ObsError); ok && v.StatusCode == 404 {
+			err = storage.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	defer output.Body.Close()
+
+	return io.ReadAll(output.Body)
+}
+
+func (impl obsImpl) List() ([]string, error) {
+	prefix := impl.cfg.Directory + "/"
+
+	input := &obs.ListObjectsInput{}
+	input.Bucket = impl.cfg.Bucket
+	input.Prefix = prefix
+
+	var paths []string
+	for {
+		output, err := impl.cli.ListObjects(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range output.Contents {
+			paths = append(paths, strings.TrimPrefix(v.Key, prefix))
+		}
+
+		if !output.IsTruncated || len(output.Contents) == 0 {
+			return paths, nil
+		}
+
+		// the next marker is returned only when the delimiter is set
+		input.Marker = output.Contents[len(output.Contents)-1].Key
+	}
 }
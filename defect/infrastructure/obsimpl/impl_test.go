@@ -0,0 +1,148 @@
+package obsimpl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	storage "github.com/opensourceways/defect-manager/defect/domain/obs"
+)
+
+func TestUpload(t *testing.T) {
+	var (
+		path, auth, body string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		path, auth, body = r.URL.Path, r.Header.Get("Authorization"), string(b)
+	}))
+	defer server.Close()
+
+	cfg := S3Config{Config{
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+		Endpoint:  server.URL,
+		Bucket:    "bulletin",
+		Directory: "defect",
+		PathStyle: true,
+	}}
+	cfg.SetDefault()
+
+	if err := Init(&cfg.Config); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Instance().Upload("2024-03-01/cvrf-openEuler-BA-2024-1001.xml", []byte("<cvrfdoc/>")); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "/bulletin/defect/2024-03-01/cvrf-openEuler-BA-2024-1001.xml"; path != want {
+		t.Errorf("want path %s, got %s", want, path)
+	}
+
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 ") {
+		t.Errorf("the request is not signed by the signature V4: %s", auth)
+	}
+
+	if body != "<cvrfdoc/>" {
+		t.Errorf("unexpected body: %s", body)
+	}
+}
+
+func TestDownloadAndList(t *testing.T) {
+	// the keys are listed one by one, so that the pagination is covered
+	keys := []string{"defect/2024-03-01/cvrf-openEuler-BA-2024-1001.xml", "defect/index.txt"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bulletin" {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, "<Error><Code>NoSuchKey</Code></Error>")
+
+			return
+		}
+
+		i := 0
+		if marker := r.URL.Query().Get("marker"); marker != "" {
+			for i < len(keys) && keys[i] <= marker {
+				i++
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprintf(w,
+			"<ListBucketResult><IsTruncated>%t</IsTruncated><Contents><Key>%s</Key></Contents></ListBucketResult>",
+			i < len(keys)-1, keys[i],
+		)
+	}))
+	defer server.Close()
+
+	cfg := S3Config{Config{
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+		Endpoint:  server.URL,
+		Bucket:    "bulletin",
+		Directory: "defect",
+		PathStyle: true,
+	}}
+	cfg.SetDefault()
+
+	if err := Init(&cfg.Config); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Instance().Download("update_defect.txt"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("want ErrNotFound, got %v", err)
+	}
+
+	paths, err := Instance().List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"2024-03-01/cvrf-openEuler-BA-2024-1001.xml", "index.txt"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("want %v, got %v", want, paths)
+	}
+}
+
+func TestUploadVerify(t *testing.T) {
+	var stored []byte
+
+	// the file is truncated by the storage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			b, _ := io.ReadAll(r.Body)
+			stored = b[:len(b)-1]
+		} else {
+			w.Write(stored)
+		}
+	}))
+	defer server.Close()
+
+	cfg := S3Config{Config{
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+		Endpoint:  server.URL,
+		Bucket:    "bulletin",
+		Directory: "defect",
+		Verify:    true,
+		PathStyle: true,
+	}}
+	cfg.SetDefault()
+
+	if err := Init(&cfg.Config); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Instance().Upload("2024-03-01/cvrf-openEuler-BA-2024-1001.xml", []byte("<cvrfdoc/>"))
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("want checksum mismatch, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+package osvimpl
+
+type Config struct {
+	IdPrefix  string `json:"id_prefix"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+func (c *Config) SetDefault() {
+	if c.IdPrefix == "" {
+		c.IdPrefix = "OEBA-"
+	}
+
+	if c.Ecosystem == "" {
+		c.Ecosystem = "openEuler"
+	}
+}
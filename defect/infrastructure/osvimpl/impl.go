@@ -0,0 +1,112 @@
+package osvimpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/utils"
+)
+
+const schemaVersion = "1.6.0"
+
+var instance *osvImpl
+
+// Init initializes the OSV generator, the modified time of records is told by the clock
+func Init(cfg *Config, c utils.Clock) {
+	instance = &osvImpl{
+		cfg:   cfg,
+		clock: c,
+	}
+}
+
+func Instance() *osvImpl {
+	return instance
+}
+
+type osvImpl struct {
+	cfg   *Config
+	clock utils.Clock
+}
+
+func (impl osvImpl) Identification(d *domain.Defect) string {
+	return impl.cfg.IdPrefix + d.Issue.Number
+}
+
+func (impl osvImpl) Generate(d *domain.Defect) ([]byte, error) {
+	now := impl.clock.Now().UTC().Format(time.RFC3339)
+
+	data := Osv{
+		SchemaVersion: schemaVersion,
+		Id:            impl.Identification(d),
+		Modified:      now,
+		Published:     time.Unix(d.AcceptedAt, 0).UTC().Format(time.RFC3339),
+		Summary:       d.Issue.Title,
+		Details:       strings.TrimSpace(d.Description),
+		Affected:      impl.affected(d),
+		References:    impl.references(d),
+		DatabaseSpecific: DatabaseSpecific{
+			Severity: d.SeverityLevel.String(),
+		},
+	}
+
+	return json.MarshalIndent(data, "", "\t")
+}
+
+// ecosystem converts the version such as openEuler-22.03-LTS to openEuler:22.03-LTS
+func (impl osvImpl) ecosystem(version string) string {
+	return fmt.Sprintf("%s:%s", impl.cfg.Ecosystem, strings.TrimPrefix(version, impl.cfg.Ecosystem+"-"))
+}
+
+func (impl osvImpl) affected(d *domain.Defect) []Affected {
+	var versions []string
+	if d.ComponentVersion != "" {
+		versions = []string{d.ComponentVersion}
+	}
+
+	affected := make([]Affected, len(d.AffectedVersion))
+	for k, v := range d.AffectedVersion {
+		affected[k] = Affected{
+			Package: Package{
+				Ecosystem: impl.ecosystem(v.String()),
+				Name:      d.Component,
+				Purl: fmt.Sprintf("pkg:rpm/%s/%s?distro=%s",
+					strings.ToLower(impl.cfg.Ecosystem), d.Component, v.String(),
+				),
+			},
+			Versions: versions,
+			EcosystemSpecific: EcosystemSpecific{
+				Severity: d.SeverityLevel.String(),
+			},
+		}
+	}
+
+	return affected
+}
+
+func (impl osvImpl) references(d *domain.Defect) []Reference {
+	refs := []Reference{{
+		Type: "REPORT",
+		Url: fmt.Sprintf("https://gitee.com/%s/%s/issues/%s",
+			d.Issue.Org, d.Issue.Repo, d.Issue.Number,
+		),
+	}}
+
+	if d.ReferenceURL != nil {
+		refs = append(refs, Reference{Type: "WEB", Url: d.ReferenceURL.URL()})
+	}
+
+	if d.GuidanceURL != nil {
+		refs = append(refs, Reference{Type: "ARTICLE", Url: d.GuidanceURL.URL()})
+	}
+
+	for _, pr := range d.MergedPR {
+		if pr.URL != nil {
+			refs = append(refs, Reference{Type: "FIX", Url: pr.URL.URL()})
+		}
+	}
+
+	return refs
+}
@@ -0,0 +1,113 @@
+package osvimpl
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+// run `go test ./defect/infrastructure/osvimpl -update` to regenerate the golden files
+var update = flag.Bool("update", false, "update the golden files")
+
+const (
+	version2003 = "openEuler-20.03-LTS-SP4"
+	version2203 = "openEuler-22.03-LTS-SP3"
+)
+
+type clockTest struct {
+	now time.Time
+}
+
+func (c clockTest) Now() time.Time {
+	return c.now
+}
+
+func systemVersions(vs ...string) []dp.SystemVersion {
+	versions := make([]dp.SystemVersion, len(vs))
+	for k, v := range vs {
+		versions[k], _ = dp.NewSystemVersion(v)
+	}
+
+	return versions
+}
+
+func url(s string) dp.URL {
+	v, _ := dp.NewURL(s)
+
+	return v
+}
+
+func testDefect(number string, versions ...string) domain.Defect {
+	severity, _ := dp.NewSeverityLevel("High")
+
+	return domain.Defect{
+		Component:       "zbar",
+		SystemVersion:   systemVersions(versions[0])[0],
+		Description:     "crash when scanning the image of " + number + "\n",
+		SeverityLevel:   severity,
+		AffectedVersion: systemVersions(versions...),
+		Issue: domain.Issue{
+			Title:  "zbar crashes " + number,
+			Number: number,
+			Org:    "src-openeuler",
+			Repo:   "zbar",
+			Status: dp.IssueStatusClosed,
+		},
+		AcceptedAt: time.Date(2024, 2, 20, 12, 0, 0, 0, time.UTC).Unix(),
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	cfg := new(Config)
+	cfg.SetDefault()
+
+	Init(cfg, clockTest{now: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)})
+
+	full := testDefect("I8ABCD", version2003, version2203)
+	full.ComponentVersion = "0.22"
+	full.ReferenceURL = url("https://github.com/mchehab/zbar/issues/12")
+	full.GuidanceURL = url("https://github.com/mchehab/zbar/wiki/12")
+	full.MergedPR = []domain.PullRequest{{
+		Version: systemVersions(version2203)[0],
+		URL:     url("https://gitee.com/src-openeuler/zbar/pulls/12"),
+	}}
+
+	cases := []struct {
+		name   string
+		defect domain.Defect
+	}{
+		{name: "full", defect: full},
+		{name: "minimal", defect: testDefect("I8ABCE", version2203)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Instance().Generate(&c.defect)
+			if err != nil {
+				t.Fatalf("generate error: %s", err.Error())
+			}
+
+			golden := filepath.Join("testdata", c.name+".json")
+			if *update {
+				if err = os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatalf("update golden file error: %s", err.Error())
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden file error: %s", err.Error())
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("the record differs from %s, run with -update if it is expected:\n%s", golden, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package osvimpl
+
+type Osv struct {
+	SchemaVersion    string           `json:"schema_version"`
+	Id               string           `json:"id"`
+	Modified         string           `json:"modified"`
+	Published        string           `json:"published"`
+	Summary          string           `json:"summary"`
+	Details          string           `json:"details"`
+	Affected         []Affected       `json:"affected"`
+	References       []Reference      `json:"references"`
+	DatabaseSpecific DatabaseSpecific `json:"database_specific"`
+}
+
+type Affected struct {
+	Package           Package           `json:"package"`
+	Versions          []string          `json:"versions,omitempty"`
+	EcosystemSpecific EcosystemSpecific `json:"ecosystem_specific"`
+}
+
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl"`
+}
+
+type EcosystemSpecific struct {
+	Severity string `json:"severity"`
+}
+
+type Reference struct {
+	Type string `json:"type"`
+	Url  string `json:"url"`
+}
+
+type DatabaseSpecific struct {
+	Severity string `json:"severity"`
+}
@@ -1,8 +1,31 @@
 package producttreeimpl
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/opensourceways/server-common-lib/utils"
+)
+
+const (
+	sourceGitee    = "gitee"
+	sourceLocal    = "local"
+	sourceHttp     = "http"
+	sourceRepodata = "repodata"
+)
+
 type Config struct {
-	Token  string `json:"token"        required:"true"`
-	PkgRPM PkgRPM `json:"pkg_rpm"      required:"true"`
+	// Source is where the lists of rpms are fetched from, gitee, local, http or repodata
+	Source string `json:"source"`
+
+	// Token and PkgRPM are used by the gitee source
+	Token  string `json:"token"`
+	PkgRPM PkgRPM `json:"pkg_rpm"`
+
+	Local    LocalSource    `json:"local"`
+	Http     HttpSource     `json:"http"`
+	Repodata RepodataSource `json:"repodata"`
 }
 
 type PkgRPM struct {
@@ -11,3 +34,77 @@ type PkgRPM struct {
 	PathPrefix string `json:"path_prefix" required:"true"`
 	Branch     string `json:"branch"      required:"true"`
 }
+
+// LocalSource is a directory which has the csv file of each version, such as openEuler-22.03-LTS.csv
+type LocalSource struct {
+	Directory string `json:"directory" required:"true"`
+}
+
+// HttpSource downloads the csv files by http(s)
+type HttpSource struct {
+	// UrlFormat has one %s, the version, such as https://example.com/latest_rpm/%s.csv
+	UrlFormat string `json:"url_format" required:"true"`
+}
+
+// RepodataSource builds the lists of rpms by the repodata of the yum repositories
+type RepodataSource struct {
+	// UrlFormat has two %s, the version and the arch, such as https://repo.openeuler.org/%s/everything/%s,
+	// it can be the path of a local directory as well
+	UrlFormat string   `json:"url_format" required:"true"`
+	Arches    []string `json:"arches"` // aarch64 and x86_64 by default
+}
+
+// SetDefault sets the default of the source selected only
+func (c *Config) SetDefault() {
+	if c.Source == "" {
+		c.Source = sourceGitee
+	}
+
+	if c.Source == sourceRepodata && len(c.Repodata.Arches) == 0 {
+		c.Repodata.Arches = []string{"aarch64", "x86_64"}
+	}
+}
+
+// Validate checks the config of the source selected only
+func (c *Config) Validate() error {
+	switch c.Source {
+	case sourceGitee:
+		if c.Token == "" {
+			return errors.New("missing token of product tree")
+		}
+
+		_, err := utils.BuildRequestBody(&c.PkgRPM, "pkg_rpm")
+
+		return err
+
+	case sourceLocal:
+		_, err := utils.BuildRequestBody(&c.Local, sourceLocal)
+
+		return err
+
+	case sourceHttp:
+		if _, err := utils.BuildRequestBody(&c.Http, sourceHttp); err != nil {
+			return err
+		}
+
+		if strings.Count(c.Http.UrlFormat, "%") != 1 || !strings.Contains(c.Http.UrlFormat, "%s") {
+			return fmt.Errorf("the url format %s must have one %%s only", c.Http.UrlFormat)
+		}
+
+		return nil
+
+	case sourceRepodata:
+		if _, err := utils.BuildRequestBody(&c.Repodata, sourceRepodata); err != nil {
+			return err
+		}
+
+		if strings.Count(c.Repodata.UrlFormat, "%") != 2 || strings.Count(c.Repodata.UrlFormat, "%s") != 2 {
+			return fmt.Errorf("the url format %s must have two %%s only", c.Repodata.UrlFormat)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("invalid product tree source: %s", c.Source)
+	}
+}
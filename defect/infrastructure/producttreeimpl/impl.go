@@ -2,7 +2,6 @@ package producttreeimpl
 
 import (
 	"bytes"
-	"encoding/base64"
 	"fmt"
 	"io"
 	"strings"
@@ -10,7 +9,6 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/opensourceways/robot-gitee-lib/client"
 	"github.com/sirupsen/logrus"
 
 	"github.com/opensourceways/defect-manager/defect/domain"
@@ -21,9 +19,7 @@ var instance *productTreeImpl
 
 func Init(cfg *Config) {
 	instance = &productTreeImpl{
-		cli: client.NewClient(func() []byte {
-			return []byte(cfg.Token)
-		}),
+		source:              newRPMSource(cfg),
 		cfg:                 cfg,
 		rpmCache:            make(map[string][]byte),
 		rpmOfComponentCache: make(map[string]string),
@@ -36,8 +32,8 @@ func Instance() *productTreeImpl {
 }
 
 type productTreeImpl struct {
-	cli client.Client
-	cfg *Config
+	source rpmSource
+	cfg    *Config
 
 	rpmCache            map[string][]byte
 	rpmOfComponentCache map[string]string
@@ -75,7 +71,7 @@ func (impl *productTreeImpl) GetTree(component string, versions []dp.SystemVersi
 		affectedRPM[v.String()] = rpm
 	}
 
-	return impl.buildTree(affectedRPM), nil
+	return impl.buildTree(versions, affectedRPM), nil
 }
 
 func (impl *productTreeImpl) parseRPM(component, version string) string {
@@ -116,19 +112,32 @@ func (impl *productTreeImpl) initRPMCache() {
 		return
 	}
 
+	// the data are put into the cache after all are fetched, because the map can't be written concurrently
+	data := make(map[string][]byte)
+	var dataLock sync.Mutex
+
 	for version := range dp.MaintainVersion {
 		v := version.String()
 		impl.wg.Add(1)
 		go func() {
-			impl.fetchRPMData(v)
+			if b, ok := impl.fetchRPMData(v); ok {
+				dataLock.Lock()
+				data[v] = b
+				dataLock.Unlock()
+			}
+
 			impl.wg.Done()
 		}()
 	}
 
 	impl.wg.Wait()
+
+	for k, v := range data {
+		impl.rpmCache[k] = v
+	}
 }
 
-func (impl *productTreeImpl) fetchRPMData(version string) {
+func (impl *productTreeImpl) fetchRPMData(version string) ([]byte, bool) {
 	count := 0
 	maxCount := 10
 	interval := time.Second * 3
@@ -136,40 +145,29 @@ func (impl *productTreeImpl) fetchRPMData(version string) {
 	for {
 		if count > maxCount {
 			logrus.Errorf("fetch rpm data of %s failed after %d times", version, maxCount)
-			break
+
+			return nil, false
 		}
 		count++
 
-		content, err := impl.cli.GetPathContent(
-			impl.cfg.PkgRPM.Org,
-			impl.cfg.PkgRPM.Repo,
-			fmt.Sprintf("%s%s.csv", impl.cfg.PkgRPM.PathPrefix, version),
-			impl.cfg.PkgRPM.Branch,
-		)
+		content, err := impl.source.fetch(version)
 		if err != nil {
 			logrus.Errorf("get content of %s error %s", version, err.Error())
 			time.Sleep(interval)
 			continue
 		}
 
-		decodeContent, err := base64.StdEncoding.DecodeString(content.Content)
-		if err != nil {
-			logrus.Errorf("base64decode content of %s error %s", version, err.Error())
-			time.Sleep(interval)
-			continue
-		}
-
-		impl.rpmCache[version] = decodeContent
-
-		break
+		return content, true
 	}
 }
 
-func (impl *productTreeImpl) buildTree(affectedRPM map[string]string) domain.ProductTree {
+// buildTree puts the rpms into the tree in the order of versions, so that the tree is the same every time
+func (impl *productTreeImpl) buildTree(versions []dp.SystemVersion, affectedRPM map[string]string) domain.ProductTree {
 	tree := make(map[dp.Arch][]domain.Product)
-	for version, rpms := range affectedRPM {
+	for _, v := range versions {
+		version := v.String()
 
-		rpmSlice := strings.Fields(rpms)
+		rpmSlice := strings.Fields(affectedRPM[version])
 		for _, rpm := range rpmSlice {
 			// example of rpm: zbar-0.22-4.oe2203.src.rpm
 			t := strings.Split(rpm, ".")
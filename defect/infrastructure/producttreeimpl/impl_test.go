@@ -0,0 +1,91 @@
+package producttreeimpl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+const testVersion = "openEuler-22.03-LTS"
+
+const testCSV = "1,zbar,zbar-0.22-4.oe2203.src.rpm zbar-0.22-4.oe2203.x86_64.rpm zbar-0.22-4.oe2203.aarch64.rpm\n" +
+	"2,kernel,kernel-5.10.0-60.oe2203.src.rpm\n"
+
+func testTree(t *testing.T, cfg *Config) {
+	dp.Init([]string{testVersion})
+
+	Init(cfg)
+
+	impl := Instance()
+	impl.InitCache()
+	defer impl.CleanCache()
+
+	v, err := dp.NewSystemVersion(testVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := impl.GetTree("zbar", []dp.SystemVersion{v})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := domain.ProductTree{
+		dp.NewArch("src"):     {{ID: "zbar-0.22-4", CPE: testVersion, FullName: "zbar-0.22-4.oe2203.src.rpm"}},
+		dp.NewArch("x86_64"):  {{ID: "zbar-0.22-4", CPE: testVersion, FullName: "zbar-0.22-4.oe2203.x86_64.rpm"}},
+		dp.NewArch("aarch64"): {{ID: "zbar-0.22-4", CPE: testVersion, FullName: "zbar-0.22-4.oe2203.aarch64.rpm"}},
+	}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("want %v, got %v", want, tree)
+	}
+}
+
+func TestLocalSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, testVersion+".csv"), []byte(testCSV), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testTree(t, &Config{Source: sourceLocal, Local: LocalSource{Directory: dir}})
+}
+
+func TestHttpSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/latest_rpm/"+testVersion+".csv" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Write([]byte(testCSV))
+	}))
+	defer server.Close()
+
+	testTree(t, &Config{Source: sourceHttp, Http: HttpSource{UrlFormat: server.URL + "/latest_rpm/%s.csv"}})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   Config
+		valid bool
+	}{
+		{"gitee without token", Config{Source: sourceGitee}, false},
+		{"local", Config{Source: sourceLocal, Local: LocalSource{Directory: "/rpms"}}, true},
+		{"local without directory", Config{Source: sourceLocal}, false},
+		{"http", Config{Source: sourceHttp, Http: HttpSource{UrlFormat: "https://example.com/%s.csv"}}, true},
+		{"http without version", Config{Source: sourceHttp, Http: HttpSource{UrlFormat: "https://example.com/a.csv"}}, false},
+		{"unknown", Config{Source: "ftp"}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: want valid %t, got %v", tt.name, tt.valid, err)
+		}
+	}
+}
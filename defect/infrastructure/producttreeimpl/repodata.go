@@ -0,0 +1,334 @@
+package producttreeimpl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var versionSegment = regexp.MustCompile(`[0-9]+|[a-zA-Z]+`)
+
+type repomd struct {
+	Data []struct {
+		Type     string `xml:"type,attr"`
+		Checksum string `xml:"checksum"`
+		Location struct {
+			Href string `xml:"href,attr"`
+		} `xml:"location"`
+	} `xml:"data"`
+}
+
+// primaryPackage keeps the fields of package which are used only, the others are skipped when decoding
+type primaryPackage struct {
+	Name    string `xml:"name"`
+	Arch    string `xml:"arch"`
+	Version struct {
+		Epoch string `xml:"epoch,attr"`
+	} `xml:"version"`
+	Location struct {
+		Href string `xml:"href,attr"`
+	} `xml:"location"`
+	SourceRPM string `xml:"format>sourcerpm"`
+}
+
+// rpmVersion is the epoch, version and release of rpm, the epoch is empty if it is 0
+type rpmVersion struct {
+	epoch   string
+	version string
+	release string
+}
+
+// sourcePackage is a source rpm and the binary rpms built from it
+type sourcePackage struct {
+	rpmVersion
+	rpm    string
+	binary map[string]bool
+}
+
+// primaryCache is the packages of the primary.xml, which is downloaded again only if
+// the location or checksum of it in the repomd.xml is changed
+type primaryCache struct {
+	key  string
+	pkgs []primaryPackage
+}
+
+// repodataSource builds the csv of rpms by the repodata of the yum repository of each arch,
+// so that all the binary rpms built from the source rpm of component are listed.
+type repodataSource struct {
+	urlFormat string
+	arches    []string
+	cli       *http.Client
+
+	lock  sync.Mutex
+	cache map[string]primaryCache
+}
+
+func (s *repodataSource) fetch(version string) ([]byte, error) {
+	components := make(map[string]*sourcePackage)
+
+	for _, arch := range s.arches {
+		pkgs, err := s.primary(strings.TrimSuffix(fmt.Sprintf(s.urlFormat, version, arch), "/"))
+		if err != nil {
+			return nil, fmt.Errorf("read repodata of %s error: %s", arch, err.Error())
+		}
+
+		addPackages(components, pkgs)
+	}
+
+	names := make([]string, 0, len(components))
+	for k := range components {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	for k, name := range names {
+		p := components[name]
+
+		rpms := make([]string, 0, len(p.binary)+1)
+		for v := range p.binary {
+			rpms = append(rpms, v)
+		}
+
+		sort.Strings(rpms)
+
+		fmt.Fprintf(&buf, "%d,%s,%s\n", k+1, name, strings.Join(append([]string{p.rpm}, rpms...), " "))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// primary reads the packages in the primary.xml of the repository, or gets them from the cache
+// if the primary.xml is not changed since it was read last time
+func (s *repodataSource) primary(base string) ([]primaryPackage, error) {
+	var md repomd
+	if err := s.decode(base+"/repodata/repomd.xml", func(r io.Reader) error {
+		return xml.NewDecoder(r).Decode(&md)
+	}); err != nil {
+		return nil, err
+	}
+
+	href, key := "", ""
+	for _, v := range md.Data {
+		if v.Type == "primary" {
+			href = v.Location.Href
+			key = href + " " + strings.TrimSpace(v.Checksum)
+		}
+	}
+
+	if href == "" {
+		return nil, fmt.Errorf("no primary in the repomd.xml of %s", base)
+	}
+
+	s.lock.Lock()
+	c, ok := s.cache[base]
+	s.lock.Unlock()
+
+	if ok && c.key == key {
+		return c.pkgs, nil
+	}
+
+	var pkgs []primaryPackage
+	err := s.decode(base+"/"+href, func(r io.Reader) error {
+		switch path.Ext(href) {
+		case ".gz":
+			gr, err := gzip.NewReader(r)
+			if err != nil {
+				return err
+			}
+
+			defer gr.Close()
+
+			r = gr
+		case ".xml":
+		default:
+			return fmt.Errorf("unsupported compression of %s", href)
+		}
+
+		var err error
+		pkgs, err = decodePackages(r)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	if s.cache == nil {
+		s.cache = make(map[string]primaryCache)
+	}
+	s.cache[base] = primaryCache{key: key, pkgs: pkgs}
+	s.lock.Unlock()
+
+	return pkgs, nil
+}
+
+// decodePackages decodes the packages one by one, so that the whole primary.xml,
+// which is hundreds of MB for a big repository, is not kept in memory.
+func decodePackages(r io.Reader) ([]primaryPackage, error) {
+	var pkgs []primaryPackage
+
+	dec := xml.NewDecoder(r)
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			return pkgs, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if v, ok := token.(xml.StartElement); ok && v.Name.Local == "package" {
+			var pkg primaryPackage
+			if err = dec.DecodeElement(&pkg, &v); err != nil {
+				return nil, err
+			}
+
+			pkgs = append(pkgs, pkg)
+		}
+	}
+}
+
+// decode reads the file by http(s), or from the local directory if it is not an url
+func (s *repodataSource) decode(url string, f func(io.Reader) error) error {
+	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		resp, err := s.cli.Get(url)
+		if err != nil {
+			return err
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %s of %s", resp.Status, url)
+		}
+
+		return f(resp.Body)
+	}
+
+	file, err := os.Open(filepath.FromSlash(strings.TrimPrefix(url, "file://")))
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	return f(file)
+}
+
+// addPackages adds the binary rpms to the source rpms which they are built from,
+// only the latest source rpm of each component is kept. The epoch of source rpm is not
+// in the name of it, so it is got from the binary rpm, which has the same epoch.
+// The epoch is not in the names of rpm files either, so it is used to compare the
+// versions only, and the products are named by the files as yum downloads them.
+func addPackages(components map[string]*sourcePackage, pkgs []primaryPackage) {
+	for _, pkg := range pkgs {
+		if pkg.SourceRPM == "" || pkg.Arch == "src" {
+			continue
+		}
+
+		name, version, release, ok := splitSourceRPM(pkg.SourceRPM)
+		if !ok {
+			continue
+		}
+
+		v := rpmVersion{epoch: pkg.Version.Epoch, version: version, release: release}
+
+		p := components[name]
+		if p == nil || p.compare(v) < 0 {
+			p = &sourcePackage{
+				rpmVersion: v,
+				rpm:        pkg.SourceRPM,
+				binary:     make(map[string]bool),
+			}
+			components[name] = p
+		}
+
+		if p.rpm == pkg.SourceRPM && p.compare(v) == 0 {
+			p.binary[path.Base(pkg.Location.Href)] = true
+		}
+	}
+}
+
+// splitSourceRPM splits the source rpm, such as zbar-0.22-4.oe2203.src.rpm
+func splitSourceRPM(rpm string) (name, version, release string, ok bool) {
+	s := strings.TrimSuffix(rpm, ".src.rpm")
+
+	i := strings.LastIndex(s, "-")
+	if i <= 0 {
+		return
+	}
+
+	j := strings.LastIndex(s[:i], "-")
+	if j <= 0 {
+		return
+	}
+
+	return s[:j], s[j+1 : i], s[i+1:], true
+}
+
+// compare compares the epochs as numbers at first, the empty epoch is 0
+func (v rpmVersion) compare(v1 rpmVersion) int {
+	if r := compareSegments(epoch(v.epoch), epoch(v1.epoch)); r != 0 {
+		return r
+	}
+
+	if r := compareSegments(v.version, v1.version); r != 0 {
+		return r
+	}
+
+	return compareSegments(v.release, v1.release)
+}
+
+func epoch(s string) string {
+	if s == "" {
+		return "0"
+	}
+
+	return s
+}
+
+// compareSegments compares the versions as rpm does, the numeric segment is newer than the alphabetic one
+func compareSegments(a, b string) int {
+	as := versionSegment.FindAllString(a, -1)
+	bs := versionSegment.FindAllString(b, -1)
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, y := as[i], bs[i]
+
+		xNum, yNum := x[0] >= '0' && x[0] <= '9', y[0] >= '0' && y[0] <= '9'
+		if xNum != yNum {
+			if xNum {
+				return 1
+			}
+
+			return -1
+		}
+
+		if xNum {
+			x, y = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+			if len(x) != len(y) {
+				return len(x) - len(y)
+			}
+		}
+
+		if v := strings.Compare(x, y); v != 0 {
+			return v
+		}
+	}
+
+	return len(as) - len(bs)
+}
@@ -0,0 +1,218 @@
+package producttreeimpl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+const testRepomd = `<?xml version="1.0" encoding="UTF-8"?>
+<repomd xmlns="http://linux.duke.edu/metadata/repo" xmlns:rpm="http://linux.duke.edu/metadata/rpm">
+  <data type="filelists"><location href="repodata/filelists.xml.gz"/></data>
+  <data type="primary"><location href="repodata/abc-primary.xml.gz"/></data>
+</repomd>`
+
+func testPackage(name, arch, sourceRPM string) string {
+	return testPackageOfEpoch(name, arch, sourceRPM, "0")
+}
+
+func testPackageOfEpoch(name, arch, sourceRPM, epoch string) string {
+	_, version, release, _ := splitSourceRPM(sourceRPM)
+	rpm := fmt.Sprintf("%s-%s-%s.%s.rpm", name, version, release, arch)
+
+	return fmt.Sprintf(`<package type="rpm">
+  <name>%s</name>
+  <arch>%s</arch>
+  <version epoch="%s" ver="%s" rel="%s"/>
+  <checksum type="sha256" pkgid="YES">0123</checksum>
+  <summary>%s</summary>
+  <location href="Packages/%s"/>
+  <format><rpm:license>MIT</rpm:license><rpm:sourcerpm>%s</rpm:sourcerpm></format>
+</package>`, name, arch, epoch, version, release, name, rpm, sourceRPM)
+}
+
+// writeRepo writes the repodata of the packages into the directory of the arch
+func writeRepo(t *testing.T, dir, arch string, pkgs ...string) {
+	repodata := filepath.Join(dir, testVersion, arch, "repodata")
+	if err := os.MkdirAll(repodata, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	fmt.Fprintf(w, `<metadata xmlns="http://linux.duke.edu/metadata/common" xmlns:rpm="http://linux.duke.edu/metadata/rpm">%s</metadata>`,
+		strings.Join(pkgs, "\n"),
+	)
+	w.Close()
+
+	if err := os.WriteFile(filepath.Join(repodata, "abc-primary.xml.gz"), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repodata, "repomd.xml"), []byte(testRepomd), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRepodataSource(t *testing.T) {
+	dir := t.TempDir()
+
+	writeRepo(t, dir, "x86_64",
+		testPackage("zbar", "x86_64", "zbar-0.22-4.oe2203.src.rpm"),
+		testPackage("zbar-devel", "x86_64", "zbar-0.22-4.oe2203.src.rpm"),
+		testPackage("zbar-help", "noarch", "zbar-0.22-4.oe2203.src.rpm"),
+		// the older one is replaced by the update
+		testPackage("zbar", "x86_64", "zbar-0.22-3.oe2203.src.rpm"),
+		testPackage("kernel", "x86_64", "kernel-5.10.0-60.10.0.oe2203.src.rpm"),
+	)
+	writeRepo(t, dir, "aarch64",
+		testPackage("zbar-help", "noarch", "zbar-0.22-4.oe2203.src.rpm"),
+		testPackage("zbar", "aarch64", "zbar-0.22-4.oe2203.src.rpm"),
+		testPackage("kernel", "aarch64", "kernel-5.10.0-9.oe2203.src.rpm"),
+	)
+
+	dp.Init([]string{testVersion})
+
+	cfg := &Config{Source: sourceRepodata, Repodata: RepodataSource{UrlFormat: dir + "/%s/%s/"}}
+	cfg.SetDefault()
+
+	Init(cfg)
+
+	impl := Instance()
+	impl.InitCache()
+	defer impl.CleanCache()
+
+	v, err := dp.NewSystemVersion(testVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := impl.GetTree("zbar", []dp.SystemVersion{v})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	product := func(name, arch string) domain.Product {
+		return domain.Product{
+			ID:       name + "-0.22-4",
+			CPE:      testVersion,
+			FullName: name + "-0.22-4.oe2203." + arch + ".rpm",
+		}
+	}
+
+	want := domain.ProductTree{
+		dp.NewArch("src"):     {product("zbar", "src")},
+		dp.NewArch("aarch64"): {product("zbar", "aarch64")},
+		dp.NewArch("x86_64"):  {product("zbar", "x86_64"), product("zbar-devel", "x86_64")},
+		dp.NewArch("noarch"):  {product("zbar-help", "noarch")},
+	}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("want %v, got %v", want, tree)
+	}
+
+	// the kernel of aarch64 is older than the one of x86_64
+	tree, err = impl.GetTree("kernel", []dp.SystemVersion{v})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(tree[dp.NewArch("aarch64")]); n != 0 {
+		t.Errorf("the binary rpms of the older source rpm are listed: %v", tree)
+	}
+}
+
+func TestRepodataSourceOfEpoch(t *testing.T) {
+	dir := t.TempDir()
+
+	// the version is lower, but the epoch is higher, the names of files have no epoch
+	writeRepo(t, dir, "x86_64",
+		testPackage("zbar", "x86_64", "zbar-0.23-1.oe2203.src.rpm"),
+		testPackageOfEpoch("zbar", "x86_64", "zbar-0.22-4.oe2203.src.rpm", "1"),
+		testPackageOfEpoch("zbar-devel", "x86_64", "zbar-0.22-4.oe2203.src.rpm", "1"),
+	)
+
+	s := &repodataSource{urlFormat: dir + "/%s/%s", arches: []string{"x86_64"}}
+
+	data, err := s.fetch(testVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "1,zbar,zbar-0.22-4.oe2203.src.rpm zbar-0.22-4.oe2203.x86_64.rpm zbar-devel-0.22-4.oe2203.x86_64.rpm\n"
+	if string(data) != want {
+		t.Errorf("want %s, got %s", want, data)
+	}
+}
+
+func TestRepodataSourceOfCache(t *testing.T) {
+	dir := t.TempDir()
+
+	writeRepo(t, dir, "x86_64", testPackage("zbar", "x86_64", "zbar-0.22-4.oe2203.src.rpm"))
+
+	s := &repodataSource{urlFormat: dir + "/%s/%s", arches: []string{"x86_64"}}
+
+	want, err := s.fetch(testVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the primary.xml is not read again, because the repomd.xml is not changed
+	primary := filepath.Join(dir, testVersion, "x86_64", "repodata", "abc-primary.xml.gz")
+	if err = os.Remove(primary); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := s.fetch(testVersion); err != nil || string(got) != string(want) {
+		t.Errorf("want %s from the cache, got %s, %v", want, got, err)
+	}
+
+	// the primary.xml is read again after the repository is updated
+	writeRepo(t, dir, "x86_64", testPackage("zbar", "x86_64", "zbar-0.22-5.oe2203.src.rpm"))
+
+	repomd := filepath.Join(dir, testVersion, "x86_64", "repodata", "repomd.xml")
+	data := strings.Replace(testRepomd, "abc-primary", "def-primary", 1)
+	if err = os.WriteFile(repomd, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.Rename(primary, strings.Replace(primary, "abc-primary", "def-primary", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.fetch(testVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(got), "zbar-0.22-5.oe2203.x86_64.rpm") {
+		t.Errorf("the updated repository is not read: %s", got)
+	}
+}
+
+func TestCompareSegments(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.10", "1.9", 1},
+		{"60.10.0.oe2203", "9.oe2203", 1},
+		{"0.22", "0.22", 0},
+		{"1.0", "1.0.1", -1},
+		{"1.0a", "1.01", -1},
+		{"2.oe2203", "2.oe2203sp1", -1},
+	}
+	for _, tt := range tests {
+		got := compareSegments(tt.a, tt.b)
+		if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+			t.Errorf("compare %s with %s: want %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
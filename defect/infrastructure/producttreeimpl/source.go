@@ -0,0 +1,95 @@
+package producttreeimpl
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opensourceways/robot-gitee-lib/client"
+)
+
+// rpmSource fetches the csv file of rpms of the version, each line of which is
+// <number>,<component>,<rpms separated by space>
+type rpmSource interface {
+	fetch(version string) ([]byte, error)
+}
+
+func newRPMSource(cfg *Config) rpmSource {
+	switch cfg.Source {
+	case sourceLocal:
+		return localSource{dir: cfg.Local.Directory}
+	case sourceHttp:
+		return httpSource{
+			urlFormat: cfg.Http.UrlFormat,
+			cli:       &http.Client{Timeout: time.Minute},
+		}
+	case sourceRepodata:
+		return &repodataSource{
+			urlFormat: cfg.Repodata.UrlFormat,
+			arches:    cfg.Repodata.Arches,
+			cli:       &http.Client{Timeout: 10 * time.Minute},
+		}
+	default:
+		return giteeSource{
+			cli: client.NewClient(func() []byte {
+				return []byte(cfg.Token)
+			}),
+			cfg: &cfg.PkgRPM,
+		}
+	}
+}
+
+// giteeSource gets the csv files from a repository of gitee by the contents api
+type giteeSource struct {
+	cli client.Client
+	cfg *PkgRPM
+}
+
+func (s giteeSource) fetch(version string) ([]byte, error) {
+	content, err := s.cli.GetPathContent(
+		s.cfg.Org, s.cfg.Repo, fmt.Sprintf("%s%s.csv", s.cfg.PathPrefix, version), s.cfg.Branch,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(content.Content)
+}
+
+// localSource reads the csv files from a local directory, so that the product tree
+// can be generated without network
+type localSource struct {
+	dir string
+}
+
+func (s localSource) fetch(version string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.dir, version+".csv"))
+}
+
+type httpSource struct {
+	urlFormat string
+	cli       *http.Client
+}
+
+func (s httpSource) fetch(version string) ([]byte, error) {
+	return httpGet(s.cli, fmt.Sprintf(s.urlFormat, version))
+}
+
+func httpGet(cli *http.Client, url string) ([]byte, error) {
+	resp, err := cli.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
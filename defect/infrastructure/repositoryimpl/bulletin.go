@@ -0,0 +1,191 @@
+package repositoryimpl
+
+import (
+	"fmt"
+
+	postgres "github.com/opensourceways/server-common-lib/postgre"
+	"gorm.io/gorm"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/repository"
+)
+
+const (
+	fieldID             = "id"
+	fieldBulletinID     = "bulletin_id"
+	fieldDefectID       = "defect_id"
+	fieldIdentification = "identification"
+	fieldWithdrawn      = "withdrawn"
+)
+
+var bulletinInstance repository.BulletinRepository
+
+var (
+	bulletinTableName       string
+	bulletinDefectTableName string
+)
+
+func initBulletin(cfg *Config) error {
+	bulletinTableName = cfg.Table.Bulletin
+	bulletinDefectTableName = cfg.Table.BulletinDefect
+
+	impl := bulletinImpl{postgres.NewDBTable(cfg.Table.Bulletin)}
+
+	bulletinInstance = impl
+
+	if err := impl.db.AutoMigrate(bulletinDO{}); err != nil {
+		return err
+	}
+
+	return impl.db.AutoMigrate(bulletinDefectDO{})
+}
+
+func BulletinInstance() repository.BulletinRepository {
+	return bulletinInstance
+}
+
+type bulletinImpl struct {
+	db dbimpl
+}
+
+// AddBulletin saves the bulletin and links it to the defects it covers in one transaction
+func (impl bulletinImpl) AddBulletin(r *domain.BulletinRecord) error {
+	do := impl.toBulletinDO(r)
+
+	return impl.db.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(bulletinTableName).Create(&do).Error; err != nil {
+			return err
+		}
+
+		for _, d := range r.Bulletin.Defects {
+			var defect defectDO
+			err := tx.Table(defectTableName).
+				Where(&defectDO{Number: d.Issue.Number, Org: d.Issue.Org}).
+				First(&defect).Error
+			if err != nil {
+				return fmt.Errorf("find defect %s/%s error: %s", d.Issue.Org, d.Issue.Number, err.Error())
+			}
+
+			link := bulletinDefectDO{
+				BulletinID: do.ID,
+				DefectID:   defect.ID,
+			}
+			if err = tx.Table(bulletinDefectTableName).Create(&link).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (impl bulletinImpl) SaveBulletin(r *domain.BulletinRecord) error {
+	do := impl.toBulletinDO(r)
+	filter := bulletinDO{
+		Identification: r.Bulletin.Identification,
+	}
+
+	return impl.db.UpdateRecord(&filter, &do)
+}
+
+func (impl bulletinImpl) FindBulletin(identification string) (r domain.BulletinRecord, err error) {
+	filter := bulletinDO{
+		Identification: identification,
+	}
+
+	var do bulletinDO
+	if err = impl.db.GetRecord(&filter, &do); err != nil {
+		if impl.db.IsRowNotFound(err) {
+			err = repository.ErrNotFound
+		}
+
+		return
+	}
+
+	records, err := impl.toBulletinRecords([]bulletinDO{do})
+	if err != nil {
+		return
+	}
+
+	return records[0], nil
+}
+
+func (impl bulletinImpl) FindBulletins(opt repository.OptToFindBulletins) ([]domain.BulletinRecord, error) {
+	query := impl.db.DB().Table(bulletinTableName).
+		Select(fmt.Sprintf("DISTINCT %s.*", bulletinTableName)).
+		Joins(fmt.Sprintf("JOIN %s ON %s.%s = %s.%s",
+			bulletinDefectTableName, bulletinDefectTableName, fieldBulletinID, bulletinTableName, fieldID,
+		)).
+		Joins(fmt.Sprintf("JOIN %s ON %s.%s = %s.%s",
+			defectTableName, defectTableName, fieldID, bulletinDefectTableName, fieldDefectID,
+		))
+
+	if opt.Number != "" {
+		query = query.Where(fmt.Sprintf("%s.%s = ?", defectTableName, fieldNumber), opt.Number)
+	}
+
+	if opt.Org != "" {
+		query = query.Where(fmt.Sprintf("%s.%s = ?", defectTableName, fieldOrg), opt.Org)
+	}
+
+	var dos []bulletinDO
+	if err := query.Order(fmt.Sprintf("%s.%s", bulletinTableName, fieldIdentification)).Find(&dos).Error; err != nil {
+		return nil, err
+	}
+
+	return impl.toBulletinRecords(dos)
+}
+
+// toBulletinRecords loads the defects linked to each bulletin
+func (impl bulletinImpl) toBulletinRecords(dos []bulletinDO) ([]domain.BulletinRecord, error) {
+	if len(dos) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int, len(dos))
+	for k, v := range dos {
+		ids[k] = v.ID
+	}
+
+	var rows []bulletinDefectRow
+	err := impl.db.DB().Table(defectTableName).
+		Select(fmt.Sprintf("%s.*, %s.%s", defectTableName, bulletinDefectTableName, fieldBulletinID)).
+		Joins(fmt.Sprintf("JOIN %s ON %s.%s = %s.%s",
+			bulletinDefectTableName, bulletinDefectTableName, fieldDefectID, defectTableName, fieldID,
+		)).
+		Where(fmt.Sprintf("%s.%s IN ?", bulletinDefectTableName, fieldBulletinID), ids).
+		Order(fmt.Sprintf("%s.%s", defectTableName, fieldCreatedAt)).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	defectsOfBulletin := make(map[int]domain.Defects)
+	for _, row := range rows {
+		defectsOfBulletin[row.BulletinID] = append(defectsOfBulletin[row.BulletinID], row.toDefect())
+	}
+
+	records := make([]domain.BulletinRecord, len(dos))
+	for k, v := range dos {
+		records[k] = v.toBulletinRecord(defectsOfBulletin[v.ID])
+	}
+
+	return records, nil
+}
+
+// FindWithdrawnDefects finds the numbers of defects which are covered by withdrawn bulletins only
+func (impl bulletinImpl) FindWithdrawnDefects() ([]string, error) {
+	var numbers []string
+	err := impl.db.DB().Table(defectTableName).
+		Joins(fmt.Sprintf("JOIN %s ON %s.%s = %s.%s",
+			bulletinDefectTableName, bulletinDefectTableName, fieldDefectID, defectTableName, fieldID,
+		)).
+		Joins(fmt.Sprintf("JOIN %s ON %s.%s = %s.%s",
+			bulletinTableName, bulletinTableName, fieldID, bulletinDefectTableName, fieldBulletinID,
+		)).
+		Group(fmt.Sprintf("%s.%s", defectTableName, fieldNumber)).
+		Having(fmt.Sprintf("bool_and(%s.%s)", bulletinTableName, fieldWithdrawn)).
+		Pluck(fmt.Sprintf("%s.%s", defectTableName, fieldNumber), &numbers).Error
+
+	return numbers, err
+}
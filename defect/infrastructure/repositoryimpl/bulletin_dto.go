@@ -0,0 +1,161 @@
+package repositoryimpl
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+type bulletinDO struct {
+	ID              int            `gorm:"column:id;primaryKey;autoIncrement"`
+	Identification  string         `gorm:"column:identification;uniqueIndex"`
+	Component       string         `gorm:"column:component"`
+	AffectedVersion pq.StringArray `gorm:"column:affected_version;type:text[];default:'{}'"`
+	Date            string         `gorm:"column:date"`
+	Xml             string         `gorm:"column:xml"`
+	UploadStatus    string         `gorm:"column:upload_status"`
+	Revisions       []revisionDO   `gorm:"column:revisions;type:jsonb;serializer:json"`
+	Withdrawn       bool           `gorm:"column:withdrawn;default:false"`
+	ProductTree     productTreeDO  `gorm:"column:product_tree;type:jsonb;serializer:json"`
+	CreatedAt       time.Time      `gorm:"column:created_at;<-:create;index"`
+	UpdatedAt       time.Time      `gorm:"column:updated_at"`
+}
+
+func (b bulletinDO) TableName() string {
+	return bulletinTableName
+}
+
+type revisionDO struct {
+	Number      int    `json:"number"`
+	Date        string `json:"date"`
+	Description string `json:"description"`
+}
+
+// productTreeDO is the products of bulletin keyed by arch
+type productTreeDO map[string][]productDO
+
+type productDO struct {
+	ID       string `json:"id"`
+	CPE      string `json:"cpe"`
+	FullName string `json:"full_name"`
+}
+
+func toProductTreeDO(tree domain.ProductTree) productTreeDO {
+	if len(tree) == 0 {
+		return nil
+	}
+
+	do := make(productTreeDO, len(tree))
+	for arch, products := range tree {
+		ps := make([]productDO, len(products))
+		for k, v := range products {
+			ps[k] = productDO{
+				ID:       v.ID,
+				CPE:      v.CPE,
+				FullName: v.FullName,
+			}
+		}
+
+		do[arch.String()] = ps
+	}
+
+	return do
+}
+
+func (do productTreeDO) toProductTree() domain.ProductTree {
+	if len(do) == 0 {
+		return nil
+	}
+
+	tree := make(domain.ProductTree, len(do))
+	for arch, products := range do {
+		ps := make([]domain.Product, len(products))
+		for k, v := range products {
+			ps[k] = domain.Product{
+				ID:       v.ID,
+				CPE:      v.CPE,
+				FullName: v.FullName,
+			}
+		}
+
+		tree[dp.NewArch(arch)] = ps
+	}
+
+	return tree
+}
+
+// bulletinDefectDO links a bulletin to the defects it covers
+type bulletinDefectDO struct {
+	ID         int `gorm:"column:id;primaryKey;autoIncrement"`
+	BulletinID int `gorm:"column:bulletin_id;uniqueIndex:idx_bulletin_defect"`
+	DefectID   int `gorm:"column:defect_id;uniqueIndex:idx_bulletin_defect;index"`
+}
+
+func (b bulletinDefectDO) TableName() string {
+	return bulletinDefectTableName
+}
+
+// bulletinDefectRow is a defect row joined with the bulletin it is linked to
+type bulletinDefectRow struct {
+	defectDO
+
+	BulletinID int `gorm:"column:bulletin_id"`
+}
+
+func (impl bulletinImpl) toBulletinDO(r *domain.BulletinRecord) bulletinDO {
+	revisions := make([]revisionDO, len(r.Bulletin.Revisions))
+	for k, v := range r.Bulletin.Revisions {
+		revisions[k] = revisionDO{
+			Number:      v.Number,
+			Date:        v.Date,
+			Description: v.Description,
+		}
+	}
+
+	return bulletinDO{
+		Identification:  r.Bulletin.Identification,
+		Component:       r.Bulletin.Component,
+		AffectedVersion: toStringArray(r.Bulletin.AffectedVersion),
+		Date:            r.Bulletin.Date,
+		Xml:             string(r.Xml),
+		UploadStatus:    r.UploadStatus.String(),
+		Revisions:       revisions,
+		Withdrawn:       r.Bulletin.Withdrawn,
+		ProductTree:     toProductTreeDO(r.Bulletin.ProductTree),
+	}
+}
+
+func (b bulletinDO) toBulletinRecord(ds domain.Defects) domain.BulletinRecord {
+	status, _ := dp.NewUploadStatus(b.UploadStatus)
+
+	// the bulletins saved before revisions were recorded have only been released once
+	revisions := domain.InitialRevisions(b.Date)
+	if len(b.Revisions) > 0 {
+		revisions = make([]domain.Revision, len(b.Revisions))
+		for k, v := range b.Revisions {
+			revisions[k] = domain.Revision{
+				Number:      v.Number,
+				Date:        v.Date,
+				Description: v.Description,
+			}
+		}
+	}
+
+	return domain.BulletinRecord{
+		Bulletin: domain.SecurityBulletin{
+			AffectedVersion: toSystemVersion(b.AffectedVersion),
+			Identification:  b.Identification,
+			Date:            b.Date,
+			Component:       b.Component,
+			Revisions:       revisions,
+			Withdrawn:       b.Withdrawn,
+			ProductTree:     b.ProductTree.toProductTree(),
+			Defects:         ds,
+		},
+		Xml:          []byte(b.Xml),
+		UploadStatus: status,
+	}
+}
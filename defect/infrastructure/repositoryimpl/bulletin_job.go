@@ -0,0 +1,87 @@
+package repositoryimpl
+
+import (
+	postgres "github.com/opensourceways/server-common-lib/postgre"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+	"github.com/opensourceways/defect-manager/defect/domain/repository"
+)
+
+var jobInstance repository.BulletinJobRepository
+
+var bulletinJobTableName string
+
+func initBulletinJob(cfg *Config) error {
+	bulletinJobTableName = cfg.Table.BulletinJob
+
+	impl := bulletinJobImpl{postgres.NewDBTable(cfg.Table.BulletinJob)}
+
+	jobInstance = impl
+
+	return impl.db.AutoMigrate(bulletinJobDO{})
+}
+
+func JobInstance() repository.BulletinJobRepository {
+	return jobInstance
+}
+
+type bulletinJobImpl struct {
+	db dbimpl
+}
+
+func (impl bulletinJobImpl) AddJob(job *domain.BulletinJob) error {
+	do := impl.toBulletinJobDO(job)
+
+	return impl.db.Insert(&do)
+}
+
+func (impl bulletinJobImpl) SaveJob(job *domain.BulletinJob) error {
+	do := impl.toBulletinJobDO(job)
+	filter := bulletinJobDO{
+		ID: job.Id,
+	}
+
+	return impl.db.UpdateRecord(&filter, &do)
+}
+
+func (impl bulletinJobImpl) FindJob(id string) (job domain.BulletinJob, err error) {
+	filter := bulletinJobDO{
+		ID: id,
+	}
+
+	var result bulletinJobDO
+	if err = impl.db.GetRecord(&filter, &result); err != nil {
+		if impl.db.IsRowNotFound(err) {
+			err = repository.ErrNotFound
+		}
+
+		return
+	}
+
+	return result.toBulletinJob(), nil
+}
+
+func (impl bulletinJobImpl) FindJobs(status dp.JobStatus) ([]domain.BulletinJob, error) {
+	filter := []postgres.ColumnFilter{
+		postgres.NewEqualFilter(fieldStatus, status.String()),
+	}
+
+	var dos []bulletinJobDO
+	err := impl.db.GetRecords(
+		filter, &dos,
+		postgres.Pagination{},
+		[]postgres.SortByColumn{
+			{Column: fieldCreatedAt},
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.BulletinJob, len(dos))
+	for k := range dos {
+		jobs[k] = dos[k].toBulletinJob()
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,83 @@
+package repositoryimpl
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/opensourceways/defect-manager/defect/domain"
+	"github.com/opensourceways/defect-manager/defect/domain/dp"
+)
+
+type bulletinJobDO struct {
+	ID          string              `gorm:"column:id;primaryKey"`
+	IssueNumber pq.StringArray      `gorm:"column:issue_number;type:text[];default:'{}'"`
+	Status      string              `gorm:"column:status"`
+	Error       string              `gorm:"column:error"`
+	Items       []bulletinJobItemDO `gorm:"column:items;type:jsonb;serializer:json"`
+	CreatedAt   time.Time           `gorm:"column:created_at;<-:create;index"`
+	UpdatedAt   time.Time           `gorm:"column:updated_at"`
+}
+
+func (j bulletinJobDO) TableName() string {
+	return bulletinJobTableName
+}
+
+type bulletinJobItemDO struct {
+	Identification  string   `json:"identification"`
+	Component       string   `json:"component"`
+	AffectedVersion []string `json:"affected_version"`
+	IssueNumber     []string `json:"issue_number"`
+	Status          string   `json:"status"`
+	Error           string   `json:"error"`
+}
+
+func (impl bulletinJobImpl) toBulletinJobDO(job *domain.BulletinJob) bulletinJobDO {
+	items := make([]bulletinJobItemDO, len(job.Items))
+	for k, v := range job.Items {
+		items[k] = bulletinJobItemDO{
+			Identification:  v.Identification,
+			Component:       v.Component,
+			AffectedVersion: toStringArray(v.AffectedVersion),
+			IssueNumber:     v.IssueNumber,
+			Status:          v.Status.String(),
+			Error:           v.Error,
+		}
+	}
+
+	return bulletinJobDO{
+		ID:          job.Id,
+		IssueNumber: job.IssueNumber,
+		Status:      job.Status.String(),
+		Error:       job.Error,
+		Items:       items,
+	}
+}
+
+func (j bulletinJobDO) toBulletinJob() domain.BulletinJob {
+	items := make([]domain.BulletinJobItem, len(j.Items))
+	for k, v := range j.Items {
+		status, _ := dp.NewJobStatus(v.Status)
+
+		items[k] = domain.BulletinJobItem{
+			Identification:  v.Identification,
+			Component:       v.Component,
+			AffectedVersion: toSystemVersion(v.AffectedVersion),
+			IssueNumber:     v.IssueNumber,
+			Status:          status,
+			Error:           v.Error,
+		}
+	}
+
+	status, _ := dp.NewJobStatus(j.Status)
+
+	return domain.BulletinJob{
+		Id:          j.ID,
+		IssueNumber: j.IssueNumber,
+		Status:      status,
+		Error:       j.Error,
+		Items:       items,
+		CreatedAt:   j.CreatedAt.Unix(),
+		UpdatedAt:   j.UpdatedAt.Unix(),
+	}
+}
@@ -0,0 +1,137 @@
+package repositoryimpl
+
+import (
+	"errors"
+
+	postgres "github.com/opensourceways/server-common-lib/postgre"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/opensourceways/defect-manager/defect/domain/repository"
+)
+
+const (
+	fieldYear    = "year"
+	fieldCurrent = "current_number"
+)
+
+var sequenceInstance repository.BulletinSequence
+
+var (
+	bulletinSequenceTableName    string
+	bulletinSequenceGapTableName string
+)
+
+func initBulletinSequence(cfg *Config) error {
+	bulletinSequenceTableName = cfg.Table.BulletinSequence
+	bulletinSequenceGapTableName = cfg.Table.BulletinSequenceGap
+
+	impl := bulletinSequenceImpl{postgres.NewDBTable(cfg.Table.BulletinSequence)}
+
+	sequenceInstance = impl
+
+	if err := impl.db.AutoMigrate(bulletinSequenceDO{}); err != nil {
+		return err
+	}
+
+	return impl.db.AutoMigrate(bulletinSequenceGapDO{})
+}
+
+func SequenceInstance() repository.BulletinSequence {
+	return sequenceInstance
+}
+
+type bulletinSequenceImpl struct {
+	db dbimpl
+}
+
+// lock creates the sequence of the year from the number if it does not exist,
+// then locks it until the transaction ends
+func (impl bulletinSequenceImpl) lock(tx *gorm.DB, year, current int) (seq bulletinSequenceDO, err error) {
+	seqOfYear := bulletinSequenceDO{
+		Year:    year,
+		Current: current,
+	}
+	err = tx.Table(bulletinSequenceTableName).Clauses(clause.OnConflict{DoNothing: true}).Create(&seqOfYear).Error
+	if err != nil {
+		return
+	}
+
+	err = tx.Table(bulletinSequenceTableName).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(fieldYear+" = ?", year).
+		First(&seq).Error
+
+	return
+}
+
+// Reconcile moves the sequence forward when the max number used by others is larger than it
+func (impl bulletinSequenceImpl) Reconcile(year, maxNum int) error {
+	return impl.db.DB().Transaction(func(tx *gorm.DB) error {
+		seq, err := impl.lock(tx, year, maxNum)
+		if err != nil || seq.Current >= maxNum {
+			return err
+		}
+
+		err = tx.Table(bulletinSequenceTableName).
+			Where(fieldYear+" = ?", year).
+			Update(fieldCurrent, maxNum).Error
+		if err != nil {
+			return err
+		}
+
+		// the numbers not larger than maxNum may have been used by others
+		return tx.Table(bulletinSequenceGapTableName).
+			Where(fieldYear+" = ? AND "+fieldNumber+" <= ?", year, maxNum).
+			Delete(&bulletinSequenceGapDO{}).Error
+	})
+}
+
+func (impl bulletinSequenceImpl) Allocate(year, start int) (num int, err error) {
+	err = impl.db.DB().Transaction(func(tx *gorm.DB) error {
+		seq, err := impl.lock(tx, year, start)
+		if err != nil {
+			return err
+		}
+
+		var gap bulletinSequenceGapDO
+		err = tx.Table(bulletinSequenceGapTableName).
+			Where(fieldYear+" = ?", year).
+			Order(fieldNumber).
+			First(&gap).Error
+		if err == nil {
+			num = gap.Number
+
+			return tx.Table(bulletinSequenceGapTableName).Delete(&gap).Error
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		num = seq.Current + 1
+
+		return tx.Table(bulletinSequenceTableName).
+			Where(fieldYear+" = ?", year).
+			Update(fieldCurrent, num).Error
+	})
+
+	return
+}
+
+// Release gives back a number which is allocated but will not be used
+func (impl bulletinSequenceImpl) Release(year, num int) error {
+	return impl.db.DB().Transaction(func(tx *gorm.DB) error {
+		seq, err := impl.lock(tx, year, num)
+		if err != nil || num > seq.Current {
+			return err
+		}
+
+		gap := bulletinSequenceGapDO{
+			Year:   year,
+			Number: num,
+		}
+
+		return tx.Table(bulletinSequenceGapTableName).Clauses(clause.OnConflict{DoNothing: true}).Create(&gap).Error
+	})
+}
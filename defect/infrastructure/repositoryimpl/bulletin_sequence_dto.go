@@ -0,0 +1,20 @@
+package repositoryimpl
+
+type bulletinSequenceDO struct {
+	Year    int `gorm:"column:year;primaryKey;autoIncrement:false"`
+	Current int `gorm:"column:current_number"`
+}
+
+func (s bulletinSequenceDO) TableName() string {
+	return bulletinSequenceTableName
+}
+
+// bulletinSequenceGapDO is a number which was allocated but has not been used
+type bulletinSequenceGapDO struct {
+	Year   int `gorm:"column:year;primaryKey;autoIncrement:false"`
+	Number int `gorm:"column:number;primaryKey;autoIncrement:false"`
+}
+
+func (s bulletinSequenceGapDO) TableName() string {
+	return bulletinSequenceGapTableName
+}
@@ -4,6 +4,37 @@ type Config struct {
 	Table Table `json:"table" required:"true"`
 }
 
+func (cfg *Config) SetDefault() {
+	cfg.Table.SetDefault()
+}
+
 type Table struct {
-	Defect string `json:"defect_manager" required:"true"`
+	Defect              string `json:"defect_manager"        required:"true"`
+	Bulletin            string `json:"bulletin"`
+	BulletinDefect      string `json:"bulletin_defect"`
+	BulletinSequence    string `json:"bulletin_sequence"`
+	BulletinSequenceGap string `json:"bulletin_sequence_gap"`
+	BulletinJob         string `json:"bulletin_job"`
+}
+
+func (t *Table) SetDefault() {
+	if t.Bulletin == "" {
+		t.Bulletin = "bulletin"
+	}
+
+	if t.BulletinDefect == "" {
+		t.BulletinDefect = "bulletin_defect"
+	}
+
+	if t.BulletinSequence == "" {
+		t.BulletinSequence = "bulletin_sequence"
+	}
+
+	if t.BulletinSequenceGap == "" {
+		t.BulletinSequenceGap = "bulletin_sequence_gap"
+	}
+
+	if t.BulletinJob == "" {
+		t.BulletinJob = "bulletin_job"
+	}
 }
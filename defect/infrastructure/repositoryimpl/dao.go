@@ -2,6 +2,7 @@ package repositoryimpl
 
 import (
 	postgres "github.com/opensourceways/server-common-lib/postgre"
+	"gorm.io/gorm"
 )
 
 type dbimpl interface {
@@ -13,6 +14,7 @@ type dbimpl interface {
 	) error
 
 	AutoMigrate(dst interface{}) error
+	DB() *gorm.DB
 
 	IsRowNotFound(error) bool
 	IsRowExists(error) bool
@@ -2,16 +2,18 @@ package repositoryimpl
 
 import (
 	postgres "github.com/opensourceways/server-common-lib/postgre"
+	"gorm.io/gorm"
 
 	"github.com/opensourceways/defect-manager/defect/domain"
 	"github.com/opensourceways/defect-manager/defect/domain/repository"
 )
 
 const (
-	fieldOrg       = "org"
-	fieldNumber    = "number"
-	fieldStatus    = "status"
-	fieldCreatedAt = "created_at"
+	fieldOrg        = "org"
+	fieldNumber     = "number"
+	fieldStatus     = "status"
+	fieldCreatedAt  = "created_at"
+	fieldAcceptedAt = "accepted_at"
 )
 
 var instance repository.DefectRepository
@@ -25,9 +27,25 @@ func Init(cfg *Config) error {
 
 	instance = impl
 
-	err := impl.db.AutoMigrate(defectDO{})
+	if err := impl.db.AutoMigrate(defectDO{}); err != nil {
+		return err
+	}
+
+	if err := impl.backfillAcceptedAt(); err != nil {
+		return err
+	}
+
+	if err := initBulletin(cfg); err != nil {
+		return err
+	}
 
-	return err
+	if err := initBulletinSequence(cfg); err != nil {
+		return err
+	}
+
+	initLock()
+
+	return initBulletinJob(cfg)
 }
 
 func Instance() repository.DefectRepository {
@@ -57,13 +75,23 @@ func (impl defectImpl) HasDefect(issue *domain.Issue) (bool, error) {
 	return true, nil
 }
 
+// backfillAcceptedAt sets the accepted time of the defects saved before it was recorded,
+// the defects were saved when they were accepted.
+func (impl defectImpl) backfillAcceptedAt() error {
+	return impl.db.DB().Table(defectTableName).
+		Where(fieldAcceptedAt+" IS NULL").
+		Update(fieldAcceptedAt, gorm.Expr(fieldCreatedAt)).Error
+}
+
 func (impl defectImpl) AddDefect(defect *domain.Defect) error {
 	do := impl.toDefectDO(defect)
 	return impl.db.Insert(&do)
 }
 
+// SaveDefect keeps the accepted time, so that the BUG ID of the defect does not change
 func (impl defectImpl) SaveDefect(defect *domain.Defect) error {
 	do := impl.toDefectDO(defect)
+	do.AcceptedAt = nil
 	filter := defectDO{
 		Number: defect.Issue.Number,
 		Org:    defect.Issue.Org,
@@ -21,12 +21,16 @@ type defectDO struct {
 	ComponentVersion string         `gorm:"column:component_version"`
 	SystemVersion    string         `gorm:"column:system_version"`
 	Description      string         `gorm:"column:description"`
+	DescriptionEn    string         `gorm:"column:description_en"`
 	ReferenceURL     string         `gorm:"column:reference_url"`
 	GuidanceURL      string         `gorm:"column:guidance_url"`
 	Influence        string         `gorm:"column:influence"`
+	InfluenceEn      string         `gorm:"column:influence_en"`
 	SeverityLevel    string         `gorm:"column:severity_level"`
 	AffectedVersion  pq.StringArray `gorm:"column:affected_version;type:text[];default:'{}'"`
 	ABI              string         `gorm:"column:abi"`
+	MergedPR         []mergedPRDO   `gorm:"column:merged_pr;type:jsonb;serializer:json"`
+	AcceptedAt       *time.Time     `gorm:"column:accepted_at"`
 	CreatedAt        time.Time      `gorm:"column:created_at;<-:create;index"`
 	UpdatedAt        time.Time      `gorm:"column:updated_at"`
 }
@@ -35,7 +39,20 @@ func (d defectDO) TableName() string {
 	return defectTableName
 }
 
+type mergedPRDO struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
 func (impl defectImpl) toDefectDO(defect *domain.Defect) defectDO {
+	mergedPR := make([]mergedPRDO, len(defect.MergedPR))
+	for k, v := range defect.MergedPR {
+		mergedPR[k] = mergedPRDO{
+			Version: v.Version.String(),
+			URL:     v.URL.URL(),
+		}
+	}
+
 	return defectDO{
 		Number:           defect.Issue.Number,
 		Title:            defect.Issue.Title,
@@ -47,15 +64,30 @@ func (impl defectImpl) toDefectDO(defect *domain.Defect) defectDO {
 		ComponentVersion: defect.ComponentVersion,
 		SystemVersion:    defect.SystemVersion.String(),
 		Description:      defect.Description,
+		DescriptionEn:    defect.DescriptionEn,
 		ReferenceURL:     defect.ReferenceURL.URL(),
 		GuidanceURL:      defect.GuidanceURL.URL(),
 		Influence:        defect.Influence,
+		InfluenceEn:      defect.InfluenceEn,
 		SeverityLevel:    defect.SeverityLevel.String(),
 		AffectedVersion:  toStringArray(defect.AffectedVersion),
 		ABI:              defect.ABI,
+		MergedPR:         mergedPR,
+		AcceptedAt:       toAcceptedAt(defect.AcceptedAt),
 	}
 }
 
+// toAcceptedAt stores NULL if the accepted time is unknown rather than the zero of unix time
+func toAcceptedAt(n int64) *time.Time {
+	if n == 0 {
+		return nil
+	}
+
+	t := time.Unix(n, 0)
+
+	return &t
+}
+
 func toStringArray(versions []dp.SystemVersion) pq.StringArray {
 	arr := make(pq.StringArray, len(versions))
 	for k, v := range versions {
@@ -82,18 +114,37 @@ func (d defectDO) toDefect() domain.Defect {
 	severityLevel, _ := dp.NewSeverityLevel(d.SeverityLevel)
 	status, _ := dp.NewIssueStatus(d.Status)
 
+	// the defect without accepted time was accepted when it was saved, as backfillAcceptedAt assumes
+	acceptedAt := d.CreatedAt
+	if d.AcceptedAt != nil {
+		acceptedAt = *d.AcceptedAt
+	}
+
+	var mergedPR []domain.PullRequest
+	for _, v := range d.MergedPR {
+		pv, _ := dp.NewSystemVersion(v.Version)
+		url, _ := dp.NewURL(v.URL)
+		mergedPR = append(mergedPR, domain.PullRequest{
+			Version: pv,
+			URL:     url,
+		})
+	}
+
 	return domain.Defect{
 		Kernel:           d.Kernel,
 		Component:        d.Component,
 		ComponentVersion: d.ComponentVersion,
 		SystemVersion:    version,
 		Description:      d.Description,
+		DescriptionEn:    d.DescriptionEn,
 		ReferenceURL:     referenceURL,
 		GuidanceURL:      guidanceURL,
 		Influence:        d.Influence,
+		InfluenceEn:      d.InfluenceEn,
 		SeverityLevel:    severityLevel,
 		AffectedVersion:  toSystemVersion(d.AffectedVersion),
 		ABI:              d.ABI,
+		MergedPR:         mergedPR,
 		Issue: domain.Issue{
 			Title:  d.Title,
 			Number: d.Number,
@@ -101,5 +152,6 @@ func (d defectDO) toDefect() domain.Defect {
 			Repo:   d.Repo,
 			Status: status,
 		},
+		AcceptedAt: acceptedAt.Unix(),
 	}
 }
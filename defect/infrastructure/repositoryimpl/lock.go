@@ -0,0 +1,48 @@
+package repositoryimpl
+
+import (
+	"fmt"
+	"time"
+
+	postgres "github.com/opensourceways/server-common-lib/postgre"
+	"gorm.io/gorm"
+
+	"github.com/opensourceways/defect-manager/defect/domain/repository"
+)
+
+var lockInstance repository.Lock
+
+func initLock() {
+	lockInstance = lockImpl{postgres.NewDBTable("")}
+}
+
+func LockInstance() repository.Lock {
+	return lockInstance
+}
+
+// lockImpl holds an advisory lock of postgres, which is shared by all the instances of the service
+type lockImpl struct {
+	db dbimpl
+}
+
+// Do bounds the wait of lock by the lock_timeout, and the holding of it by the
+// idle_in_transaction_session_timeout, with which postgres ends the session and
+// releases the lock if the transaction is not finished in time.
+func (impl lockImpl) Do(name string, timeout time.Duration, f func() error) error {
+	ms := timeout.Milliseconds()
+
+	return impl.db.DB().Transaction(func(tx *gorm.DB) error {
+		for _, v := range []string{"lock_timeout", "idle_in_transaction_session_timeout"} {
+			if err := tx.Exec(fmt.Sprintf("SET LOCAL %s = %d", v, ms)).Error; err != nil {
+				return err
+			}
+		}
+
+		// the lock is released when the transaction ends
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", name).Error; err != nil {
+			return fmt.Errorf("lock %s error: %s", name, err.Error())
+		}
+
+		return f()
+	})
+}
@@ -54,8 +54,50 @@ const docTemplate = `{
             }
         },
         "/v1/defect/bulletin": {
+            "get": {
+                "description": "list security bulletins which cover the defect",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Defect"
+                ],
+                "summary": "list security bulletins which cover the defect",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "issue number of the defect",
+                        "name": "number",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "org of the issue",
+                        "name": "org",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/app.BulletinDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "generate security bulletin for some defects",
+                "description": "generate security bulletin for some defects asynchronously, the progress can be got by the job",
                 "consumes": [
                     "application/json"
                 ],
@@ -77,6 +119,236 @@ const docTemplate = `{
                 "responses": {
                     "201": {
                         "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/app.BulletinJobDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/defect/bulletin/jobs/{id}": {
+            "get": {
+                "description": "get the job of generating security bulletins, including the result of each bulletin",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Defect"
+                ],
+                "summary": "get the job of generating security bulletins",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of the job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.BulletinJobDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/defect/bulletin/preview": {
+            "post": {
+                "description": "generate security bulletin for some defects without uploading it and allocating identification",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Defect"
+                ],
+                "summary": "preview security bulletin for some defects",
+                "parameters": [
+                    {
+                        "description": "body of some issue number",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.bulletinRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/app.BulletinPreviewDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/defect/bulletin/signing-key": {
+            "get": {
+                "description": "get the fingerprint of the OpenPGP key which verifies the detached signatures of bulletins",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Defect"
+                ],
+                "summary": "get the signing key of security bulletins",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.SigningKeyDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/defect/bulletin/{id}": {
+            "put": {
+                "description": "re-issue the security bulletin with a new revision under the same identification",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Defect"
+                ],
+                "summary": "update security bulletin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "identification of the bulletin",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body of the revision",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.updateBulletinRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/defect/bulletin/{id}/document": {
+            "get": {
+                "description": "get the security bulletin in the format, such as cvrf, csaf, markdown and html",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Defect"
+                ],
+                "summary": "get document of security bulletin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "identification of the bulletin",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "format of the document, cvrf by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/defect/bulletin/{id}/withdrawal": {
+            "post": {
+                "description": "withdraw the security bulletin published in error, the defects covered by it can be collected again",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Defect"
+                ],
+                "summary": "withdraw security bulletin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "identification of the bulletin",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body of the reason",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.withdrawBulletinRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "type": "string"
                         }
@@ -89,9 +361,175 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/v1/defect/osv": {
+            "get": {
+                "description": "export the defects accepted after the date as OSV records",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Defect"
+                ],
+                "summary": "export accepted defects as OSV records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "export defects after the date",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "app.BulletinDTO": {
+            "type": "object",
+            "properties": {
+                "affected_version": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "component": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "identification": {
+                    "type": "string"
+                },
+                "issue_number": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "upload_status": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "withdrawn": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "app.BulletinJobDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issue_number": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.BulletinJobItemDTO"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.BulletinJobItemDTO": {
+            "type": "object",
+            "properties": {
+                "affected_version": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "component": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "identification": {
+                    "type": "string"
+                },
+                "issue_number": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.BulletinPreviewDTO": {
+            "type": "object",
+            "properties": {
+                "affected_version": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "component": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "grouping": {
+                    "type": "string"
+                },
+                "identification": {
+                    "type": "string"
+                },
+                "issue_number": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "xml": {
+                    "type": "string"
+                }
+            }
+        },
         "app.CollectDefectsDTO": {
             "type": "object",
             "properties": {
@@ -101,23 +539,54 @@ const docTemplate = `{
                 "issue_id": {
                     "type": "string"
                 },
+                "issue_url": {
+                    "type": "string"
+                },
                 "score": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
                 },
+                "title": {
+                    "type": "string"
+                },
                 "version": {
                     "type": "string"
                 }
             }
         },
+        "app.SigningKeyDTO": {
+            "type": "object",
+            "properties": {
+                "fingerprint": {
+                    "type": "string"
+                }
+            }
+        },
         "controller.bulletinRequest": {
             "type": "object",
             "required": [
                 "issue_number"
             ],
             "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "grouping": {
+                    "description": "Grouping is the policy of grouping defects into bulletins: auto, component, component_version or manual",
+                    "type": "string"
+                },
+                "groups": {
+                    "description": "Groups are the issue numbers of each bulletin, which are required by the manual grouping",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "issue_number": {
                     "type": "array",
                     "items": {
@@ -125,6 +594,28 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "controller.updateBulletinRequest": {
+            "type": "object",
+            "required": [
+                "description"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.withdrawBulletinRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -15,6 +15,7 @@ import (
 	"github.com/opensourceways/defect-manager/defect/app"
 	"github.com/opensourceways/defect-manager/defect/domain"
 	"github.com/opensourceways/defect-manager/defect/domain/dp"
+	localutils "github.com/opensourceways/defect-manager/utils"
 )
 
 var Instance *eventHandler
@@ -148,7 +149,8 @@ func (impl eventHandler) HandleNoteEvent(e *sdk.NoteEvent) error {
 		return commentIssue(strings.Replace(err.Error(), ". ", "\n\n", -1))
 	}
 
-	if err = impl.checkRelatedPR(e, commentInfo.AffectedVersion); err != nil {
+	mergedPR, err := impl.checkRelatedPR(e, commentInfo.AffectedVersion)
+	if err != nil {
 		return commentIssue(err.Error())
 	}
 
@@ -156,7 +158,7 @@ func (impl eventHandler) HandleNoteEvent(e *sdk.NoteEvent) error {
 		return fmt.Errorf("close issue error: %s", err.Error())
 	}
 
-	cmd, err := impl.toCmd(e, issueInfo, commentInfo)
+	cmd, err := impl.toCmd(e, issueInfo, commentInfo, mergedPR)
 	if err != nil {
 		return fmt.Errorf("to cmd error: %s", err.Error())
 	}
@@ -200,8 +202,9 @@ func (impl eventHandler) approveCmdReplyToComment(e *sdk.NoteEvent) string {
 	return ""
 }
 
-func (impl eventHandler) toCmd(e *sdk.NoteEvent, issue parseIssueResult, comment parseCommentResult) (
-	cmd app.CmdToSaveDefect, err error) {
+func (impl eventHandler) toCmd(
+	e *sdk.NoteEvent, issue parseIssueResult, comment parseCommentResult, mergedPR []domain.PullRequest,
+) (cmd app.CmdToSaveDefect, err error) {
 	systemVersion, err := dp.NewSystemVersion(issue.SystemVersion)
 	if err != nil {
 		return
@@ -237,12 +240,16 @@ func (impl eventHandler) toCmd(e *sdk.NoteEvent, issue parseIssueResult, comment
 		ComponentVersion: issue.ComponentVersion,
 		SystemVersion:    systemVersion,
 		Description:      issue.Description,
+		DescriptionEn:    issue.DescriptionEn,
 		ReferenceURL:     referenceUrl,
 		GuidanceURL:      guidanceUrl,
 		Influence:        comment.Influence,
+		InfluenceEn:      comment.InfluenceEn,
 		SeverityLevel:    securityLevel,
 		AffectedVersion:  affectedVersion,
 		ABI:              strings.Join(comment.Abi, ","),
+		MergedPR:         mergedPR,
+		AcceptedAt:       localutils.Now(),
 		Issue: domain.Issue{
 			Title:  e.Issue.Title,
 			Number: e.Issue.Number,
@@ -253,31 +260,56 @@ func (impl eventHandler) toCmd(e *sdk.NoteEvent, issue parseIssueResult, comment
 	}, nil
 }
 
-func (impl eventHandler) checkRelatedPR(e *sdk.NoteEvent, versions []string) error {
+// checkRelatedPR checks that the pull requests of all the affected versions have been merged,
+// and returns the merged pull requests of them
+func (impl eventHandler) checkRelatedPR(e *sdk.NoteEvent, versions []string) ([]domain.PullRequest, error) {
 	endpoint := fmt.Sprintf("https://gitee.com/api/v5/repos/%v/issues/%v/pull_requests?access_token=%s&repo=%s",
 		e.Project.Namespace, e.Issue.Number, impl.cfg.RobotToken, e.Project.Name,
 	)
 	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var prs []sdk.PullRequest
 	cli := utils.NewHttpClient(3)
 	bytes, _, err := cli.Download(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := json.Unmarshal(bytes, &prs); err != nil {
-		return err
+		return nil, err
 	}
 
+	affectedVersion := sets.NewString(versions...)
 	mergedVersion := sets.NewString()
+	var mergedPR []domain.PullRequest
 	for _, pr := range prs {
-		if pr.State == sdk.StatusMerged {
-			mergedVersion.Insert(pr.Base.Ref)
+		if pr.State != sdk.StatusMerged {
+			continue
+		}
+
+		mergedVersion.Insert(pr.Base.Ref)
+
+		if !affectedVersion.Has(pr.Base.Ref) {
+			continue
 		}
+
+		version, err := dp.NewSystemVersion(pr.Base.Ref)
+		if err != nil {
+			continue
+		}
+
+		url, err := dp.NewURL(pr.HtmlUrl)
+		if err != nil {
+			continue
+		}
+
+		mergedPR = append(mergedPR, domain.PullRequest{
+			Version: version,
+			URL:     url,
+		})
 	}
 
 	var relatedPRNotMerged []string
@@ -288,8 +320,8 @@ func (impl eventHandler) checkRelatedPR(e *sdk.NoteEvent, versions []string) err
 	}
 
 	if len(relatedPRNotMerged) != 0 {
-		return fmt.Errorf("受影响分支关联pr未合入: %s", strings.Join(relatedPRNotMerged, ","))
+		return nil, fmt.Errorf("受影响分支关联pr未合入: %s", strings.Join(relatedPRNotMerged, ","))
 	}
 
-	return nil
+	return mergedPR, nil
 }
@@ -72,6 +72,42 @@ func (t serviceTest) CollectDefects(time time.Time) ([]app.CollectDefectsDTO, er
 	return nil, nil
 }
 
-func (t serviceTest) GenerateBulletins([]string) error {
+func (t serviceTest) CreateBulletinJob(app.CmdToGenerateBulletins) (app.BulletinJobDTO, error) {
+	return app.BulletinJobDTO{}, nil
+}
+
+func (t serviceTest) GenerateBulletins(string, app.CmdToGenerateBulletins) error {
 	return nil
 }
+
+func (t serviceTest) GetBulletinJob(string) (app.BulletinJobDTO, error) {
+	return app.BulletinJobDTO{}, nil
+}
+
+func (t serviceTest) PreviewBulletins(app.CmdToGenerateBulletins) ([]app.BulletinPreviewDTO, error) {
+	return nil, nil
+}
+
+func (t serviceTest) FindBulletins(app.CmdToFindBulletins) ([]app.BulletinDTO, error) {
+	return nil, nil
+}
+
+func (t serviceTest) UpdateBulletin(app.CmdToUpdateBulletin) error {
+	return nil
+}
+
+func (t serviceTest) WithdrawBulletin(app.CmdToWithdrawBulletin) error {
+	return nil
+}
+
+func (t serviceTest) GetBulletinDocument(app.CmdToGetBulletinDocument) (app.BulletinDocumentDTO, error) {
+	return app.BulletinDocumentDTO{}, nil
+}
+
+func (t serviceTest) GetSigningKey() (app.SigningKeyDTO, error) {
+	return app.SigningKeyDTO{}, nil
+}
+
+func (t serviceTest) ExportOSV(time.Time) ([]app.OsvDTO, error) {
+	return nil, nil
+}
@@ -26,6 +26,10 @@ const (
 	itemAffectedVersion = "affectedVersion"
 	itemAbi             = "abi"
 
+	// the English translations are optional
+	itemDescriptionEn = "descriptionEn"
+	itemInfluenceEn   = "influenceEn"
+
 	severityLevelLow      = "Low"
 	severityLevelModerate = "Moderate"
 	severityLevelHigh     = "High"
@@ -47,19 +51,23 @@ var (
 		itemSeverityLevel:   "严重等级",
 		itemAffectedVersion: "受影响版本",
 		itemAbi:             "abi",
+		itemDescriptionEn:   "缺陷简述(英文)",
+		itemInfluenceEn:     "影响性分析说明(英文)",
 	}
 
 	regexpOfItems = map[string]*regexp.Regexp{
 		itemKernel:          regexp.MustCompile(`(\*\*内核信息)[:：]\*\*([\s\S]*?)\*\*缺陷归属组件`),
 		itemComponents:      regexp.MustCompile(`(缺陷归属组件)[:：]\*\*([\s\S]*?)\*\*缺陷归属的版本`),
 		itemSystemVersion:   regexp.MustCompile(`(缺陷归属的版本)[:：]\*\*([\s\S]*?)\*\*缺陷简述`),
-		itemDescription:     regexp.MustCompile(`(缺陷简述)[:：]\*\*([\s\S]*?)\*\*【环境信息`),
+		itemDescription:     regexp.MustCompile(`(缺陷简述)[:：]\*\*([\s\S]*?)\*\*(?:缺陷简述[(（]英文[)）]|【环境信息)`),
 		itemReferenceUrl:    regexp.MustCompile(`(缺陷详情参考链接)[:：]\*\*([\s\S]*?)\*\*缺陷分析指导链接`),
 		itemGuidanceUrl:     regexp.MustCompile(`(缺陷分析指导链接)[:：]\*\*([\s\S]*?)$`),
-		itemInfluence:       regexp.MustCompile(`(影响性分析说明)[:：]([\s\S]*?)缺陷严重等级`),
+		itemInfluence:       regexp.MustCompile(`(影响性分析说明)[:：]([\s\S]*?)(?:影响性分析说明[(（]英文[)）]|缺陷严重等级)`),
 		itemSeverityLevel:   regexp.MustCompile(`(缺陷严重等级)[:：]\(Critical/High/Moderate/Low\)([\s\S]*?)受影响版本排查`),
 		itemAffectedVersion: regexp.MustCompile(`(受影响版本排查)\(受影响/不受影响\)[:：]([\s\S]*?)abi变化`),
 		itemAbi:             regexp.MustCompile(`(abi变化)\(受影响/不受影响\)[:：]([\s\S]*?)$`),
+		itemDescriptionEn:   regexp.MustCompile(`(缺陷简述[(（]英文[)）])[:：]\*\*([\s\S]*?)\*\*【环境信息`),
+		itemInfluenceEn:     regexp.MustCompile(`(影响性分析说明[(（]英文[)）])[:：]([\s\S]*?)缺陷严重等级`),
 	}
 
 	sortOfIssueItems = []string{
@@ -78,9 +86,19 @@ var (
 		itemAbi,
 	}
 
+	optionalIssueItems = []string{
+		itemDescriptionEn,
+	}
+
+	optionalCommentItems = []string{
+		itemInfluenceEn,
+	}
+
 	noTrimItem = map[string]bool{
-		itemDescription: true,
-		itemInfluence:   true,
+		itemDescription:   true,
+		itemInfluence:     true,
+		itemDescriptionEn: true,
+		itemInfluenceEn:   true,
 	}
 
 	severityLevelMap = map[string]bool{
@@ -102,12 +120,14 @@ type parseIssueResult struct {
 	ComponentVersion string
 	SystemVersion    string
 	Description      string
+	DescriptionEn    string
 	ReferenceUrl     string
 	GuidanceUrl      string
 }
 
 type parseCommentResult struct {
 	Influence       string
+	InfluenceEn     string
 	SeverityLevel   string
 	AffectedVersion []string
 	Abi             []string
@@ -157,6 +177,9 @@ func (impl eventHandler) parseIssue(body string) (parseIssueResult, error) {
 		ret.GuidanceUrl = v
 	}
 
+	optional := impl.parseOptional(optionalIssueItems, body)
+	ret.DescriptionEn = optional[itemDescriptionEn]
+
 	return ret, nil
 }
 
@@ -193,6 +216,9 @@ func (impl eventHandler) parseComment(body string) (parseCommentResult, error) {
 		ret.Abi = abi
 	}
 
+	optional := impl.parseOptional(optionalCommentItems, body)
+	ret.InfluenceEn = optional[itemInfluenceEn]
+
 	return ret, nil
 }
 
@@ -204,24 +230,18 @@ func (impl eventHandler) parse(items []string, body string) (map[string]string,
 
 	parseResult := make(map[string]string)
 	for _, item := range items {
-		match := regexpOfItems[item].FindAllStringSubmatch(body, -1)
-		if len(match) < 1 || len(match[regMatchResult]) < 3 {
+		v, found := matchItem(item, body)
+		if !found {
 			mr.Add(fmt.Sprintf("%s 解析失败", itemName[item]))
 			continue
 		}
 
-		matchItem := match[regMatchResult][regMatchItem]
-		trimItemInfo := localutils.TrimString(matchItem)
-		if trimItemInfo == "" {
+		if v == "" {
 			mr.Add(fmt.Sprintf("%s 不允许为空", itemName[item]))
 			continue
 		}
 
-		if _, ok := noTrimItem[item]; ok {
-			parseResult[item] = matchItem
-		} else {
-			parseResult[item] = trimItemInfo
-		}
+		parseResult[item] = v
 
 		switch item {
 		case itemSeverityLevel:
@@ -244,6 +264,35 @@ func (impl eventHandler) parse(items []string, body string) (map[string]string,
 	return parseResult, mr.Err()
 }
 
+// parseOptional parses the items which may be absent or empty
+func (impl eventHandler) parseOptional(items []string, body string) map[string]string {
+	parseResult := make(map[string]string)
+	for _, item := range items {
+		if v, _ := matchItem(item, body); v != "" {
+			parseResult[item] = v
+		}
+	}
+
+	return parseResult
+}
+
+// matchItem returns the value of item in the body, which is empty if there is nothing but blanks.
+// found is false if the item is absent.
+func matchItem(item, body string) (value string, found bool) {
+	match := regexpOfItems[item].FindAllStringSubmatch(body, -1)
+	if len(match) < 1 || len(match[regMatchResult]) < 3 {
+		return
+	}
+
+	v := match[regMatchResult][regMatchItem]
+	trimmed := localutils.TrimString(v)
+	if trimmed == "" || !noTrimItem[item] {
+		return trimmed, true
+	}
+
+	return v, true
+}
+
 func (impl eventHandler) parseVersion(s string) ([]string, error) {
 	reg := regexp.MustCompile(`(openEuler.*?)[:：]\s*([是否])`)
 	matches := reg.FindAllStringSubmatch(s, -1)
@@ -0,0 +1,121 @@
+package issue
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testVersion = "openEuler-22.03-LTS-SP3"
+
+func testIssueBody(descriptionEn string) string {
+	return "**内核信息：**\nLinux 5.10.0\n" +
+		"**缺陷归属组件：**zbar-0.22\n" +
+		"**缺陷归属的版本：**" + testVersion + "\n" +
+		"**缺陷简述：**\n扫描图片时崩溃\n" +
+		descriptionEn +
+		"**【环境信息】**\n" +
+		"**缺陷详情参考链接：**https://github.com/mchehab/zbar/issues/12\n" +
+		"**缺陷分析指导链接：**https://github.com/mchehab/zbar/wiki/12"
+}
+
+func testCommentBody(influenceEn string) string {
+	return "影响性分析说明：\n进程退出\n" +
+		influenceEn +
+		"缺陷严重等级：(Critical/High/Moderate/Low)\nHigh\n" +
+		"受影响版本排查(受影响/不受影响)：\n" + testVersion + ":是\n" +
+		"abi变化(受影响/不受影响)：\n" + testVersion + ":否"
+}
+
+func TestParseIssue(t *testing.T) {
+	h := eventHandler{cfg: &Config{MaintainVersion: []string{testVersion}}}
+
+	tests := []struct {
+		name          string
+		body          string
+		description   string
+		descriptionEn string
+	}{
+		{
+			name:        "without English",
+			body:        testIssueBody(""),
+			description: "\n扫描图片时崩溃\n",
+		},
+		{
+			name:        "empty English",
+			body:        testIssueBody("**缺陷简述(英文)：**\n\n"),
+			description: "\n扫描图片时崩溃\n",
+		},
+		{
+			name:          "with English",
+			body:          testIssueBody("**缺陷简述（英文）：**\ncrash when scanning the image\n"),
+			description:   "\n扫描图片时崩溃\n",
+			descriptionEn: "\ncrash when scanning the image\n",
+		},
+	}
+	for _, tt := range tests {
+		ret, err := h.parseIssue(tt.body)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err.Error())
+		}
+
+		if ret.Description != tt.description || ret.DescriptionEn != tt.descriptionEn {
+			t.Errorf("%s: want %q and %q, got %q and %q",
+				tt.name, tt.description, tt.descriptionEn, ret.Description, ret.DescriptionEn,
+			)
+		}
+
+		if ret.Component != "zbar" || ret.ComponentVersion != "0.22" || ret.SystemVersion != testVersion {
+			t.Errorf("%s: unexpected result %+v", tt.name, ret)
+		}
+	}
+}
+
+func TestParseComment(t *testing.T) {
+	h := eventHandler{cfg: &Config{MaintainVersion: []string{testVersion}}}
+
+	tests := []struct {
+		name        string
+		body        string
+		influence   string
+		influenceEn string
+	}{
+		{
+			name:      "without English",
+			body:      testCommentBody(""),
+			influence: "\n进程退出\n",
+		},
+		{
+			name:        "with English",
+			body:        testCommentBody("影响性分析说明(英文)：\nthe process exits\n"),
+			influence:   "\n进程退出\n",
+			influenceEn: "\nthe process exits\n",
+		},
+	}
+	for _, tt := range tests {
+		ret, err := h.parseComment(tt.body)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err.Error())
+		}
+
+		if ret.Influence != tt.influence || ret.InfluenceEn != tt.influenceEn {
+			t.Errorf("%s: want %q and %q, got %q and %q",
+				tt.name, tt.influence, tt.influenceEn, ret.Influence, ret.InfluenceEn,
+			)
+		}
+
+		if ret.SeverityLevel != "High" || !reflect.DeepEqual(ret.AffectedVersion, []string{testVersion}) {
+			t.Errorf("%s: unexpected result %+v", tt.name, ret)
+		}
+	}
+}
+
+func TestParseEmptyItem(t *testing.T) {
+	h := eventHandler{cfg: &Config{MaintainVersion: []string{testVersion}}}
+
+	body := testCommentBody("")
+	body = "影响性分析说明：\n \n" + body[len("影响性分析说明：\n进程退出\n"):]
+
+	if _, err := h.parseComment(body); err == nil {
+		t.Error("the empty influence is accepted")
+	}
+}
@@ -18,14 +18,19 @@ import (
 	"github.com/opensourceways/defect-manager/defect/app"
 	"github.com/opensourceways/defect-manager/defect/controller"
 	"github.com/opensourceways/defect-manager/defect/domain/dp"
+	"github.com/opensourceways/defect-manager/defect/domain/obs"
 	"github.com/opensourceways/defect-manager/defect/infrastructure/backendimpl"
 	"github.com/opensourceways/defect-manager/defect/infrastructure/bulletinimpl"
+	"github.com/opensourceways/defect-manager/defect/infrastructure/gitimpl"
+	"github.com/opensourceways/defect-manager/defect/infrastructure/localimpl"
 	"github.com/opensourceways/defect-manager/defect/infrastructure/obsimpl"
+	"github.com/opensourceways/defect-manager/defect/infrastructure/osvimpl"
 	"github.com/opensourceways/defect-manager/defect/infrastructure/producttreeimpl"
 	"github.com/opensourceways/defect-manager/defect/infrastructure/repositoryimpl"
 	"github.com/opensourceways/defect-manager/docs"
 	"github.com/opensourceways/defect-manager/issue"
 	messageserver "github.com/opensourceways/defect-manager/message-server"
+	"github.com/opensourceways/defect-manager/utils"
 )
 
 type options struct {
@@ -84,8 +89,9 @@ func main() {
 		return
 	}
 
-	if err = obsimpl.Init(&cfg.Obs); err != nil {
-		logrus.Errorf("init obs failed, err:%s", err.Error())
+	storage, err := initStorage(cfg)
+	if err != nil {
+		logrus.Errorf("init %s storage failed, err:%s", cfg.Storage, err.Error())
 
 		return
 	}
@@ -109,24 +115,74 @@ func main() {
 
 	backendimpl.Init(&cfg.Backend)
 
-	bulletinimpl.Init(&cfg.Bulletin)
+	if err = bulletinimpl.Init(&cfg.Bulletin); err != nil {
+		logrus.Errorf("init bulletin failed, err:%s", err.Error())
+
+		return
+	}
+
+	osvimpl.Init(&cfg.Osv, utils.SystemClock())
 
 	producttreeimpl.Init(&cfg.ProductTree)
 
 	issue.InitCommitterInstance()
 
-	run(cfg, o)
+	run(cfg, o, storage)
+}
+
+// initStorage initializes the storage which the bulletins are uploaded to
+func initStorage(cfg *config.Config) (obs.OBS, error) {
+	switch cfg.Storage {
+	case config.StorageLocal:
+		err := localimpl.Init(&cfg.Local)
+
+		return localimpl.Instance(), err
+	case config.StorageS3:
+		err := obsimpl.Init(&cfg.S3.Config)
+
+		return obsimpl.Instance(), err
+	case config.StorageGit:
+		err := gitimpl.Init(&cfg.Git, utils.SystemClock())
+
+		return gitimpl.Instance(), err
+	default:
+		err := obsimpl.Init(&cfg.Obs)
+
+		return obsimpl.Instance(), err
+	}
 }
 
-func run(cfg *config.Config, o options) {
+func run(cfg *config.Config, o options, storage obs.OBS) {
 	service := app.NewDefectService(
 		repositoryimpl.Instance(),
+		repositoryimpl.BulletinInstance(),
+		repositoryimpl.SequenceInstance(),
+		repositoryimpl.JobInstance(),
+		repositoryimpl.LockInstance(),
 		producttreeimpl.Instance(),
 		bulletinimpl.Instance(),
+		bulletinimpl.Identifier(),
+		bulletinimpl.StructureChecker(),
+		bulletinimpl.Signer(),
+		bulletinimpl.ExtraFormats(),
 		backendimpl.Instance(),
-		obsimpl.Instance(),
+		storage,
+		osvimpl.Instance(),
+		utils.SystemClock(),
 	)
 
+	if err := service.ReconcileBulletinSequence(); err != nil {
+		logrus.Errorf("reconcile bulletin sequence failed, err:%s", err.Error())
+
+		return
+	}
+
+	if err := service.FailInterruptedJobs(); err != nil {
+		logrus.Errorf("fail interrupted bulletin jobs failed, err:%s", err.Error())
+
+		return
+	}
+
 	if err := issue.InitEventHandler(&cfg.Issue, service); err != nil {
 		logrus.Errorf("init event handler failed, err:%s", err.Error())
 
@@ -150,10 +206,20 @@ func run(cfg *config.Config, o options) {
 		controller.AddRouteForDefectController(
 			v1, app.NewDefectService(
 				repositoryimpl.Instance(),
+				repositoryimpl.BulletinInstance(),
+				repositoryimpl.SequenceInstance(),
+				repositoryimpl.JobInstance(),
+				repositoryimpl.LockInstance(),
 				producttreeimpl.Instance(),
 				bulletinimpl.Instance(),
+				bulletinimpl.Identifier(),
+				bulletinimpl.StructureChecker(),
+				bulletinimpl.Signer(),
+				bulletinimpl.ExtraFormats(),
 				backendimpl.Instance(),
-				obsimpl.Instance(),
+				storage,
+				osvimpl.Instance(),
+				utils.SystemClock(),
 			),
 		)
 		engine.UseRawPath = true
@@ -31,3 +31,18 @@ func Date() string {
 func Year() int {
 	return time.Now().Year()
 }
+
+// Clock tells the current time, it can be replaced so that the output depending on time is reproducible
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (c systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func SystemClock() Clock {
+	return systemClock{}
+}